@@ -0,0 +1,98 @@
+package cloudinary
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// adminURL returns the Admin API URL of path, relative to the cloud
+// root, such as resources/image/tags/<tag>.
+func (s *cloudinaryService) adminURL(path string) string {
+	return s.adminURI.String() + strings.TrimPrefix(path, "/")
+}
+
+// callAdminAPI sends a request authenticated with the API key and secret
+// to the Admin API and decodes the JSON response into result when
+// non-nil. Parameters are sent in the query string for GET and DELETE
+// requests, and form encoded in the body otherwise. Requests changing the
+// account must use writeAdminAPI instead.
+func (s *cloudinaryService) callAdminAPI(method, path string, params url.Values, result interface{}) error {
+	uri := s.adminURL(path)
+	var req *http.Request
+	var err error
+	if method == http.MethodGet || method == http.MethodDelete {
+		if len(params) > 0 {
+			uri += "?" + params.Encode()
+		}
+		req, err = http.NewRequest(method, uri, nil)
+	} else {
+		req, err = http.NewRequest(method, uri, strings.NewReader(params.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return s.doAdminRequest(req, result)
+}
+
+// callAdminAPIJSON is like callAdminAPI but sends body encoded as JSON,
+// as required by the search and metadata fields endpoints.
+func (s *cloudinaryService) callAdminAPIJSON(method, path string, body interface{}, result interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return s.sendAdminAPIJSON(method, path, b, result)
+}
+
+func (s *cloudinaryService) sendAdminAPIJSON(method, path string, b []byte, result interface{}) error {
+	req, err := http.NewRequest(method, s.adminURL(path), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return s.doAdminRequest(req, result)
+}
+
+// writeAdminAPI is like callAdminAPI for the requests changing the
+// account: in simulation mode, they're only recorded in the plan under
+// operation, and result is left untouched.
+func (s *cloudinaryService) writeAdminAPI(operation, method, path string, params url.Values, result interface{}) error {
+	if s.simulate {
+		s.planAdminAPI(operation, method, path, params, nil)
+		return nil
+	}
+	return s.callAdminAPI(method, path, params, result)
+}
+
+// writeAdminAPIJSON is like writeAdminAPI but sends body encoded as JSON.
+func (s *cloudinaryService) writeAdminAPIJSON(operation, method, path string, body interface{}, result interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	if s.simulate {
+		s.planAdminAPI(operation, method, path, nil, b)
+		return nil
+	}
+	return s.sendAdminAPIJSON(method, path, b, result)
+}
+
+func (s *cloudinaryService) doAdminRequest(req *http.Request, result interface{}) error {
+	req.SetBasicAuth(s.apiKey, s.apiSecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if rl := parseRateLimit(resp); rl != nil {
+		s.rateLimits.set(rl)
+	}
+	return decodeHttpResponse(resp, result)
+}
@@ -0,0 +1,104 @@
+package cloudinary
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveOptions select the resources of an archive and how it is built.
+// At least one of PublicIDs, Prefixes or Tags must be set.
+type ArchiveOptions struct {
+	PublicIDs       []string              // Resources to include
+	Prefixes        []string              // Include the resources whose public id starts with any prefix
+	Tags            []string              // Include the resources with any of the tags
+	Type            DeliveryType          // Delivery type of the resources, upload by default
+	Transformations []TransformationChain // Include a derived version per transformation instead of the originals
+	TargetFormat    string                // zip (default) or tgz
+	TargetPublicID  string                // Public id of the stored archive
+	FlattenFolders  bool                  // Store all the files at the root of the archive
+	ExpiresAt       time.Time             // Expiry of the archive URL
+}
+
+func (o *ArchiveOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if len(o.PublicIDs) > 0 {
+		params["public_ids[]"] = o.PublicIDs
+	}
+	if len(o.Prefixes) > 0 {
+		params["prefixes[]"] = o.Prefixes
+	}
+	if len(o.Tags) > 0 {
+		params["tags[]"] = o.Tags
+	}
+	if o.Type != "" {
+		params.Set("type", string(o.Type))
+	}
+	if len(o.Transformations) > 0 {
+		chains := make([]string, len(o.Transformations))
+		for i, t := range o.Transformations {
+			chains[i] = t.String()
+		}
+		params.Set("transformations", strings.Join(chains, "|"))
+	}
+	if o.TargetFormat != "" {
+		params.Set("target_format", o.TargetFormat)
+	}
+	if o.TargetPublicID != "" {
+		params.Set("target_public_id", o.TargetPublicID)
+	}
+	if o.FlattenFolders {
+		params.Set("flatten_folders", "true")
+	}
+	if !o.ExpiresAt.IsZero() {
+		params.Set("expires_at", strconv.FormatInt(o.ExpiresAt.Unix(), 10))
+	}
+	return params
+}
+
+// ArchiveResult describes an archive stored by CreateArchive.
+type ArchiveResult struct {
+	PublicId      string `json:"public_id"`
+	Version       int    `json:"version"`
+	Url           string `json:"url"`
+	SecureUrl     string `json:"secure_url"`
+	Size          int    `json:"bytes"`          // In bytes
+	FileCount     int    `json:"file_count"`     // Number of files in the archive
+	ResourceCount int    `json:"resource_count"` // Number of resources archived
+}
+
+// CreateArchive builds an archive of resources of type rtype and stores
+// it as a raw resource.
+func (s *cloudinaryService) CreateArchive(rtype ResourceType, opts *ArchiveOptions) (*ArchiveResult, error) {
+	data := opts.values()
+	data.Set("mode", "create")
+	if s.simulate {
+		s.planUploadAPI("generate_archive", rtype, data.Get("target_public_id"), 0, data)
+		return &ArchiveResult{PublicId: data.Get("target_public_id")}, nil
+	}
+
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "generate_archive"), s.signedParameters(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	res := new(ArchiveResult)
+	if err := decodeHttpResponse(resp, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DownloadArchiveURL returns a signed URL generating on the fly and
+// downloading an archive of resources of type rtype. Nothing is stored
+// in the cloud.
+func (s *cloudinaryService) DownloadArchiveURL(rtype ResourceType, opts *ArchiveOptions) string {
+	data := opts.values()
+	data.Set("mode", "download")
+	return s.uploadEndpoint(rtype, "generate_archive") + "?" + s.signedParameters(data).Encode()
+}
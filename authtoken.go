@@ -0,0 +1,140 @@
+package cloudinary
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultAuthTokenName = "__cld_token__"
+
+// AuthTokenOptions configure the tokens granting access to resources
+// delivered with the authenticated type, when token based authentication
+// is enabled on the account.
+type AuthTokenOptions struct {
+	Key        string        // Hex encoded token key from the account settings
+	StartTime  time.Time     // Start of validity, now by default
+	Duration   time.Duration // Validity from StartTime, used when Expiration is zero
+	Expiration time.Time     // End of validity
+	ACL        string        // Path pattern the token grants access to, e.g. /image/authenticated/*
+	URL        string        // Path the token grants access to, used when ACL is empty
+	IP         string        // Only grant access to this client IP
+	TokenName  string        // Name of the token parameter, __cld_token__ by default
+}
+
+// authTokenUnsafe holds the characters escaped in the token ACL and URL.
+const authTokenUnsafe = " \"#%&'/:;<=>?@[\\]^`{|}~"
+
+// escapeToLower percent-encodes the unsafe characters of s with lower case
+// hexadecimal digits, as expected by the token signature.
+func escapeToLower(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x80 || c < 0x20 || strings.IndexByte(authTokenUnsafe, c) != -1 {
+			fmt.Fprintf(&b, "%%%02x", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// GenerateAuthToken returns a name=value token authorizing access to
+// authenticated resources, such as
+// __cld_token__=st=1600000000~exp=1600003600~acl=%2fimage%2f*~hmac=...
+// It can be sent as a cookie or appended to the query string of a URL.
+func GenerateAuthToken(opts AuthTokenOptions) (string, error) {
+	key, err := hex.DecodeString(opts.Key)
+	if err != nil || len(key) == 0 {
+		return "", errors.New("invalid auth token key, must be hex encoded")
+	}
+	start := opts.StartTime
+	if start.IsZero() {
+		start = time.Now()
+	}
+	expiration := opts.Expiration
+	if expiration.IsZero() {
+		if opts.Duration <= 0 {
+			return "", errors.New("auth token requires an expiration or a duration")
+		}
+		expiration = start.Add(opts.Duration)
+	}
+	if opts.ACL == "" && opts.URL == "" {
+		return "", errors.New("auth token requires an ACL or a URL")
+	}
+
+	parts := []string{}
+	if opts.IP != "" {
+		parts = append(parts, "ip="+opts.IP)
+	}
+	if !opts.StartTime.IsZero() {
+		parts = append(parts, "st="+strconv.FormatInt(start.Unix(), 10))
+	}
+	parts = append(parts, "exp="+strconv.FormatInt(expiration.Unix(), 10))
+	if opts.ACL != "" {
+		parts = append(parts, "acl="+escapeToLower(opts.ACL))
+	}
+	// The URL is signed but not part of the token
+	toSign := parts
+	if opts.ACL == "" {
+		toSign = append(append([]string{}, parts...), "url="+escapeToLower(opts.URL))
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join(toSign, "~")))
+	parts = append(parts, "hmac="+hex.EncodeToString(mac.Sum(nil)))
+
+	name := opts.TokenName
+	if name == "" {
+		name = defaultAuthTokenName
+	}
+	return name + "=" + strings.Join(parts, "~"), nil
+}
+
+// AuthToken sets the token options used by BuildURL to sign the URLs of
+// authenticated resources. Unless opts has an ACL, each token only grants
+// access to the URL it is appended to. A nil opts disables tokens.
+func (s *cloudinaryService) AuthToken(opts *AuthTokenOptions) error {
+	if opts != nil {
+		check := *opts
+		check.URL = "/"
+		if _, err := GenerateAuthToken(check); err != nil {
+			return err
+		}
+	}
+	s.authToken = opts
+	return nil
+}
+
+// signedURL appends to uri the auth token of opts, or of the service
+// configuration if opts is nil.
+func (s *cloudinaryService) signedURL(uri string, opts *AuthTokenOptions) string {
+	if opts == nil {
+		opts = s.authToken
+	}
+	if opts == nil {
+		return uri
+	}
+	tokenOpts := *opts
+	if tokenOpts.ACL == "" {
+		// Sign the path of the URL, without scheme and host
+		path := uri
+		if i := strings.Index(path, "://"); i != -1 {
+			path = path[i+3:]
+		}
+		if i := strings.Index(path, "/"); i != -1 {
+			path = path[i:]
+		}
+		tokenOpts.URL = path
+	}
+	token, err := GenerateAuthToken(tokenOpts)
+	if err != nil {
+		return uri
+	}
+	return uri + "?" + token
+}
@@ -0,0 +1,88 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Context holds the key/value contextual metadata of a resource, such as
+// its alt text or caption.
+type Context map[string]string
+
+var contextEscaper = strings.NewReplacer(`=`, `\=`, `|`, `\|`)
+
+// encode returns the context as a key1=value1|key2=value2 string, with
+// = and | escaped in keys and values.
+func (c Context) encode() string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = contextEscaper.Replace(k) + "=" + contextEscaper.Replace(c[k])
+	}
+	return strings.Join(pairs, "|")
+}
+
+// UnmarshalJSON decodes the context returned by the API, which nests the
+// key/value pairs under a "custom" key.
+func (c *Context) UnmarshalJSON(b []byte) error {
+	var nested struct {
+		Custom map[string]string `json:"custom"`
+	}
+	if err := json.Unmarshal(b, &nested); err == nil && nested.Custom != nil {
+		*c = nested.Custom
+		return nil
+	}
+	var flat map[string]string
+	if err := json.Unmarshal(b, &flat); err != nil {
+		return err
+	}
+	*c = flat
+	return nil
+}
+
+// AddContext adds the key/value pairs of ctx to the resources with the
+// given public ids and returns the public ids of the updated resources.
+// Existing keys are overwritten.
+func (s *cloudinaryService) AddContext(ctx Context, publicIDs []string, rtype ResourceType) ([]string, error) {
+	data := url.Values{
+		"command":      []string{"add"},
+		"context":      []string{ctx.encode()},
+		"public_ids[]": publicIDs,
+	}
+	return s.context(data, rtype)
+}
+
+// RemoveAllContext removes all the contextual metadata of the resources
+// with the given public ids.
+func (s *cloudinaryService) RemoveAllContext(publicIDs []string, rtype ResourceType) ([]string, error) {
+	data := url.Values{
+		"command":      []string{"remove_all"},
+		"public_ids[]": publicIDs,
+	}
+	return s.context(data, rtype)
+}
+
+func (s *cloudinaryService) context(data url.Values, rtype ResourceType) ([]string, error) {
+	if s.simulate {
+		s.planUploadAPI("context", rtype, "", 0, data)
+		return data["public_ids[]"], nil
+	}
+
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "context"), s.signedParameters(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	res := new(publicIdsResponse)
+	if err := decodeHttpResponse(resp, res); err != nil {
+		return nil, err
+	}
+	return res.PublicIds, nil
+}
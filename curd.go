@@ -1,92 +1,124 @@
 package cloudinary
 
 import (
-	"crypto/sha1"
 	"errors"
-	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 )
 
+// DestroyOptions are the optional parameters of DeleteWithOptions.
+type DestroyOptions struct {
+	Type       DeliveryType // Delivery type of the resource, upload by default
+	Invalidate bool         // Invalidate the CDN cached copies of the resource
+}
+
+// RenameOptions are the optional parameters of RenameWithOptions.
+type RenameOptions struct {
+	Type       DeliveryType // Current delivery type of the resource, upload by default
+	ToType     DeliveryType // New delivery type of the resource, unchanged if empty
+	Overwrite  bool         // Overwrite an existing resource with the target public id
+	Invalidate bool         // Invalidate the CDN cached copies of the resource
+}
+
 // Delete deletes a resource uploaded to Cloudinary.
 func (s *cloudinaryService) Delete(publicId, prepend string, rtype ResourceType) error {
-	// TODO: also delete resource entry from database (if used)
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return s.DeleteWithOptions(publicId, prepend, rtype, nil)
+}
+
+// DeleteWithOptions deletes a resource uploaded to Cloudinary. opts may be nil.
+func (s *cloudinaryService) DeleteWithOptions(publicId, prepend string, rtype ResourceType, opts *DestroyOptions) error {
 	data := url.Values{
-		"api_key":   []string{s.apiKey},
 		"public_id": []string{prepend + publicId},
-		"timestamp": []string{timestamp},
+	}
+	if opts != nil {
+		if opts.Type != "" {
+			data.Set("type", string(opts.Type))
+		}
+		if opts.Invalidate {
+			data.Set("invalidate", "true")
+		}
 	}
 	if s.keepFilesPattern != nil {
 		if s.keepFilesPattern.MatchString(prepend + publicId) {
-			fmt.Println("keep")
+			s.logInfo("destroy skipped", "operation", "destroy", "public_id", prepend+publicId, "resource_type", resourceTypeName(rtype), "status", "keep")
 			return nil
 		}
 	}
 	if s.simulate {
-		fmt.Println("ok")
+		s.planUploadAPI("destroy", rtype, prepend+publicId, 0, data)
 		return nil
 	}
 
-	// Signature
-	hash := sha1.New()
-	part := fmt.Sprintf("public_id=%s&timestamp=%s%s", prepend+publicId, timestamp, s.apiSecret)
-	io.WriteString(hash, part)
-	data.Set("signature", fmt.Sprintf("%x", hash.Sum(nil)))
-
-	rt := imageType
-	if rtype == RawType {
-		rt = rawType
-	}
-	resp, err := http.PostForm(fmt.Sprintf("%s/%s/%s/destroy/", baseUploadURL, s.cloudName, rt), data)
+	start := time.Now()
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "destroy"), s.signedParameters(data))
 	if err != nil {
+		s.logOperation("destroy", prepend+publicId, rtype, start, "", err)
 		return err
 	}
+	defer resp.Body.Close()
 
 	m, err := handleHttpResponse(resp)
+	result, _ := m["result"].(string)
+	s.logOperation("destroy", prepend+publicId, rtype, start, result, err)
 	if err != nil {
 		return err
 	}
-	if e, ok := m["result"]; ok {
-		fmt.Println(e.(string))
+	if s.store != nil {
+		return s.store.Delete(prepend + publicId)
 	}
 	return nil
 }
 
+// Rename changes the public id of a resource uploaded to Cloudinary.
 func (s *cloudinaryService) Rename(publicID, toPublicID, prepend string, rtype ResourceType) error {
+	return s.RenameWithOptions(publicID, toPublicID, prepend, rtype, nil)
+}
+
+// RenameWithOptions changes the public id of a resource uploaded to
+// Cloudinary. opts may be nil.
+func (s *cloudinaryService) RenameWithOptions(publicID, toPublicID, prepend string, rtype ResourceType, opts *RenameOptions) error {
 	publicID = strings.TrimPrefix(publicID, "/")
 	toPublicID = strings.TrimPrefix(toPublicID, "/")
-	timestamp := fmt.Sprintf(`%d`, time.Now().Unix())
 	data := url.Values{
-		"api_key":        []string{s.apiKey},
 		"from_public_id": []string{prepend + publicID},
-		"timestamp":      []string{timestamp},
 		"to_public_id":   []string{prepend + toPublicID},
 	}
-	// Signature
-	hash := sha1.New()
-	part := fmt.Sprintf("from_public_id=%s&timestamp=%s&to_public_id=%s%s", prepend+publicID, timestamp, toPublicID, s.apiSecret)
-	io.WriteString(hash, part)
-	data.Set("signature", fmt.Sprintf("%x", hash.Sum(nil)))
-
-	rt := imageType
-	if rtype == RawType {
-		rt = rawType
+	if opts != nil {
+		if opts.Type != "" {
+			data.Set("type", string(opts.Type))
+		}
+		if opts.ToType != "" {
+			data.Set("to_type", string(opts.ToType))
+		}
+		if opts.Overwrite {
+			data.Set("overwrite", "true")
+		}
+		if opts.Invalidate {
+			data.Set("invalidate", "true")
+		}
 	}
-	resp, err := http.PostForm(fmt.Sprintf("%s/%s/%s/rename", baseUploadURL, s.cloudName, rt), data)
+	if s.simulate {
+		s.planUploadAPI("rename", rtype, prepend+publicID, 0, data)
+		return nil
+	}
+
+	start := time.Now()
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "rename"), s.signedParameters(data))
 	if err != nil {
+		s.logOperation("rename", prepend+publicID, rtype, start, "", err)
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return errors.New(string(body))
+		err := errors.New(string(body))
+		s.logOperation("rename", prepend+publicID, rtype, start, resp.Status, err)
+		return err
 	}
-	return nil
+	s.logOperation("rename", prepend+publicID, rtype, start, resp.Status, nil)
+	return s.recordRename(prepend+publicID, prepend+toPublicID)
 }
@@ -0,0 +1,77 @@
+package cloudinary
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DeleteDerivedOptions are the optional parameters of
+// DeleteDerivedByTransformation.
+type DeleteDerivedOptions struct {
+	Type       DeliveryType // Delivery type of the resources, upload by default
+	Invalidate bool         // Invalidate the CDN cached copies of the derived resources
+}
+
+type deletedResponse struct {
+	Deleted map[string]string `json:"deleted"`
+}
+
+// DeleteDerivedResources deletes the derived resources with the given
+// ids, as listed in ResourceDetails.Derived, and returns the deletion
+// status of each of them.
+func (s *cloudinaryService) DeleteDerivedResources(ids ...string) (map[string]string, error) {
+	params := url.Values{
+		"derived_resource_ids[]": ids,
+	}
+	res := new(deletedResponse)
+	if err := s.writeAdminAPI("delete_derived_resources", http.MethodDelete, "derived_resources", params, res); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return simulatedDeletion(ids), nil
+	}
+	return res.Deleted, nil
+}
+
+// DeleteDerivedByTransformation deletes the versions of the given
+// resources derived with any of transformations, keeping the original
+// resources, and returns the deletion status of each resource. opts may be
+// nil.
+func (s *cloudinaryService) DeleteDerivedByTransformation(publicIDs []string, transformations []TransformationChain, rtype ResourceType, opts *DeleteDerivedOptions) (map[string]string, error) {
+	chains := make([]string, len(transformations))
+	for i, t := range transformations {
+		chains[i] = t.String()
+	}
+	params := url.Values{
+		"public_ids[]":    publicIDs,
+		"keep_original":   []string{"true"},
+		"transformations": []string{strings.Join(chains, "|")},
+	}
+	dtype := DeliveryUpload
+	if opts != nil {
+		if opts.Type != "" {
+			dtype = opts.Type
+		}
+		if opts.Invalidate {
+			params.Set("invalidate", "true")
+		}
+	}
+	path := "resources/" + resourceTypeName(rtype) + "/" + string(dtype)
+	res := new(deletedResponse)
+	if err := s.writeAdminAPI("delete_derived_by_transformation", http.MethodDelete, path, params, res); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return simulatedDeletion(publicIDs), nil
+	}
+	return res.Deleted, nil
+}
+
+func simulatedDeletion(ids []string) map[string]string {
+	deleted := make(map[string]string, len(ids))
+	for _, id := range ids {
+		deleted[id] = "deleted"
+	}
+	return deleted
+}
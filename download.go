@@ -0,0 +1,43 @@
+package cloudinary
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PrivateDownloadOptions are the optional parameters of
+// PrivateDownloadURL.
+type PrivateDownloadOptions struct {
+	ResourceType ResourceType // Image by default
+	Type         DeliveryType // Delivery type, private by default
+	ExpiresAt    time.Time    // Expiry of the URL, one hour from now by default
+	Attachment   bool         // Download the file instead of displaying it
+}
+
+// PrivateDownloadURL returns a signed URL downloading the private or
+// authenticated resource designed by publicID in the given format, such as
+// jpg or pdf. opts may be nil.
+func (s *cloudinaryService) PrivateDownloadURL(publicID, format string, opts *PrivateDownloadOptions) string {
+	if opts == nil {
+		opts = &PrivateDownloadOptions{}
+	}
+	dtype := opts.Type
+	if dtype == "" {
+		dtype = DeliveryPrivate
+	}
+	expiresAt := opts.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(time.Hour)
+	}
+	data := url.Values{
+		"public_id":  []string{publicID},
+		"format":     []string{format},
+		"type":       []string{string(dtype)},
+		"expires_at": []string{strconv.FormatInt(expiresAt.Unix(), 10)},
+	}
+	if opts.Attachment {
+		data.Set("attachment", "true")
+	}
+	return s.uploadEndpoint(opts.ResourceType, "download") + "?" + s.signedParameters(data).Encode()
+}
@@ -0,0 +1,100 @@
+package cloudinary
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ExplicitOptions are the optional parameters of Explicit.
+type ExplicitOptions struct {
+	Eager                []Eager  // Derived versions to generate
+	EagerAsync           bool     // Generate the derived versions in the background
+	EagerNotificationURL string   // Called when asynchronous eager generation completes
+	Tags                 []string // Replace the tags of the resource
+	Context              Context  // Replace the contextual metadata of the resource
+	Invalidate           bool     // Invalidate the CDN cached copies of the resource
+	NotificationURL      string   // Called when the operation completes
+}
+
+func (o *ExplicitOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if len(o.Eager) > 0 {
+		params.Set("eager", encodeEager(o.Eager))
+	}
+	if o.EagerAsync {
+		params.Set("eager_async", "true")
+	}
+	if o.EagerNotificationURL != "" {
+		params.Set("eager_notification_url", o.EagerNotificationURL)
+	}
+	if len(o.Tags) > 0 {
+		params.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if len(o.Context) > 0 {
+		params.Set("context", o.Context.encode())
+	}
+	if o.Invalidate {
+		params.Set("invalidate", "true")
+	}
+	if o.NotificationURL != "" {
+		params.Set("notification_url", o.NotificationURL)
+	}
+	return params
+}
+
+// EagerResult is a derived version generated by an eager transformation.
+type EagerResult struct {
+	Transformation string `json:"transformation"`
+	Format         string `json:"format"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	Size           int    `json:"bytes"`      // In bytes
+	Url            string `json:"url"`        // Remote url
+	SecureUrl      string `json:"secure_url"` // Over https
+}
+
+// ExplicitResult is the response of Explicit.
+type ExplicitResult struct {
+	PublicId     string         `json:"public_id"`
+	Version      int            `json:"version"`
+	Format       string         `json:"format"`
+	ResourceType string         `json:"resource_type"`
+	Type         string         `json:"type"` // Delivery type
+	Url          string         `json:"url"`
+	SecureUrl    string         `json:"secure_url"`
+	Tags         []string       `json:"tags"`
+	Context      Context        `json:"context"`
+	Eager        []*EagerResult `json:"eager"`  // Empty when EagerAsync is set
+	Status       string         `json:"status"` // pending for asynchronous generation
+}
+
+// Explicit applies actions to an already uploaded resource: generating
+// eager derived versions, or updating its tags and context. dtype is the
+// delivery type of the resource, upload if empty. opts may be nil.
+func (s *cloudinaryService) Explicit(publicID string, rtype ResourceType, dtype DeliveryType, opts *ExplicitOptions) (*ExplicitResult, error) {
+	data := opts.values()
+	data.Set("public_id", publicID)
+	if dtype == "" {
+		dtype = DeliveryUpload
+	}
+	data.Set("type", string(dtype))
+	if s.simulate {
+		s.planUploadAPI("explicit", rtype, publicID, 0, data)
+		return &ExplicitResult{PublicId: publicID, ResourceType: resourceTypeName(rtype), Type: string(dtype)}, nil
+	}
+
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "explicit"), s.signedParameters(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	res := new(ExplicitResult)
+	if err := decodeHttpResponse(resp, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
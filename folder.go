@@ -0,0 +1,106 @@
+package cloudinary
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Folder is an asset folder.
+type Folder struct {
+	Name string `json:"name"`
+	Path string `json:"path"` // Full path from the root folder
+}
+
+// FolderList is a page of folders returned by ListRootFolders and
+// ListSubFolders.
+type FolderList struct {
+	pagination
+	Folders    []*Folder `json:"folders"`
+	TotalCount int       `json:"total_count"`
+}
+
+// ListFoldersOptions are the optional parameters of ListRootFolders and
+// ListSubFolders.
+type ListFoldersOptions struct {
+	MaxResults int    // Maximum number of folders to return, up to 500
+	NextCursor string // Cursor of the page to return
+}
+
+func (o *ListFoldersOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if o.MaxResults > 0 {
+		params.Set("max_results", strconv.Itoa(o.MaxResults))
+	}
+	if o.NextCursor != "" {
+		params.Set("next_cursor", o.NextCursor)
+	}
+	return params
+}
+
+// folderPath returns the Admin API path of the folder, escaping each path
+// segment.
+func folderPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return "folders/" + strings.Join(segments, "/")
+}
+
+// ListRootFolders lists the top level folders. opts may be nil.
+func (s *cloudinaryService) ListRootFolders(opts *ListFoldersOptions) (*FolderList, error) {
+	list := new(FolderList)
+	if err := s.callAdminAPI(http.MethodGet, "folders", opts.values(), list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ListSubFolders lists the direct subfolders of path. opts may be nil.
+func (s *cloudinaryService) ListSubFolders(path string, opts *ListFoldersOptions) (*FolderList, error) {
+	list := new(FolderList)
+	if err := s.callAdminAPI(http.MethodGet, folderPath(path), opts.values(), list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateFolder creates the folder path, including its missing parents.
+func (s *cloudinaryService) CreateFolder(path string) (*Folder, error) {
+	folder := new(Folder)
+	if err := s.writeAdminAPI("create_folder", http.MethodPost, folderPath(path), nil, folder); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return &Folder{Name: path[strings.LastIndex(path, "/")+1:], Path: path}, nil
+	}
+	return folder, nil
+}
+
+// RenameFolder moves the folder path and its content to toPath.
+func (s *cloudinaryService) RenameFolder(path, toPath string) error {
+	params := url.Values{
+		"to_folder": []string{strings.Trim(toPath, "/")},
+	}
+	return s.writeAdminAPI("rename_folder", http.MethodPut, folderPath(path), params, nil)
+}
+
+// DeleteFolder deletes the empty folder path and returns the paths of the
+// deleted folders.
+func (s *cloudinaryService) DeleteFolder(path string) ([]string, error) {
+	res := new(struct {
+		Deleted []string `json:"deleted"`
+	})
+	if err := s.writeAdminAPI("delete_folder", http.MethodDelete, folderPath(path), nil, res); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return []string{path}, nil
+	}
+	return res.Deleted, nil
+}
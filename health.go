@@ -0,0 +1,44 @@
+package cloudinary
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CredentialsError is returned by HealthCheck, and by Run when the
+// startup check is enabled, when the API rejects the key and secret of
+// the account.
+type CredentialsError struct {
+	CloudName string
+	Err       *APIError
+}
+
+func (e *CredentialsError) Error() string {
+	return fmt.Sprintf("cloudinary: invalid credentials for cloud %q: %s", e.CloudName, e.Err.Message)
+}
+
+func (e *CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// HealthCheck calls the Admin API ping endpoint to check that the service
+// is reachable and the credentials are valid. It returns a
+// *CredentialsError if they aren't.
+func (s *cloudinaryService) HealthCheck() error {
+	var res struct {
+		Status string `json:"status"`
+	}
+	err := s.callAdminAPI(http.MethodGet, "ping", nil, &res)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return &CredentialsError{CloudName: s.cloudName, Err: apiErr}
+	}
+	if err != nil {
+		return err
+	}
+	if res.Status != "ok" {
+		return fmt.Errorf("cloudinary: unexpected ping status %q", res.Status)
+	}
+	return nil
+}
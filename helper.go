@@ -1,12 +1,21 @@
 package cloudinary
 
 import (
+	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"hash"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // cleanAssetName returns an asset name from the parent dirname and
@@ -103,23 +112,90 @@ func setPath(prependPath, fileName string) string {
 }
 
 // getAccessURL to get the file URL
-func getAccessURL(resType ResourceType, cloudName, publicId, extensionName string) string {
-	var t string
-	switch resType {
+func (s *cloudinaryService) getAccessURL(resType ResourceType, publicId, extensionName string) string {
+	opts := &URLOptions{ResourceType: resType}
+	// non-image resource PublicID remain extension
+	if resourceTypeName(resType) == imageType {
+		opts.Format = extensionName
+	}
+	return s.BuildURL(publicId, opts)
+}
+
+// resourceTypeName returns the resource_type segment used by the upload,
+// admin and delivery URLs for rtype. PDF files are stored as images.
+func resourceTypeName(rtype ResourceType) string {
+	switch rtype {
 	case PdfType:
-		t = "pdf"
+		return pdfType
 	case VideoType:
-		t = "video"
+		return videoType
 	case RawType:
-		t = "raw"
+		return rawType
 	default:
-		t = "image"
+		return imageType
 	}
-	// non-image resource PublicID remain extension
-	if t != "image" {
-		return baseResourceURL + "/" + cloudName + "/" + t + "/" + "upload/" + publicId
+}
+
+// uploadEndpoint returns the upload API URL of action for rtype, such as
+// https://api.cloudinary.com/v1_1/<cloud>/image/destroy.
+func (s *cloudinaryService) uploadEndpoint(rtype ResourceType, action string) string {
+	return fmt.Sprintf("%s/%s/%s", s.apiBaseURL(), resourceTypeName(rtype), action)
+}
+
+// signParameters returns the signature of params expected by the upload
+// API: the parameters are sorted by name, joined as key=value pairs with
+// & and suffixed with the API secret before hashing with the configured
+// signature algorithm. Empty parameters and the ones Cloudinary doesn't
+// sign (file, api_key, resource_type, cloud_name) are skipped.
+func (s *cloudinaryService) signParameters(params url.Values) string {
+	// Array parameters such as public_ids[] are signed by their bare name
+	// with comma separated values.
+	signed := make(map[string]string, len(params))
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		switch k {
+		case "file", "api_key", "resource_type", "cloud_name", "signature":
+			continue
+		}
+		if len(v) == 0 || (len(v) == 1 && v[0] == "") {
+			continue
+		}
+		name := strings.TrimSuffix(k, "[]")
+		signed[name] = strings.Join(v, ",")
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + signed[k]
 	}
-	return baseResourceURL + "/" + cloudName + "/" + t + "/" + "upload/" + publicId + "." + extensionName
+	var h hash.Hash
+	if s.signatureAlgorithm == "sha256" {
+		h = sha256.New()
+	} else {
+		h = sha1.New()
+	}
+	io.WriteString(h, strings.Join(parts, "&")+s.apiSecret)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// signedParameters adds the api_key, timestamp and signature parameters
+// to params and returns it.
+func (s *cloudinaryService) signedParameters(params url.Values) url.Values {
+	params.Set("timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	params.Set("signature", s.signParameters(params))
+	params.Set("api_key", s.apiKey)
+	return params
+}
+
+// APIError is an error returned by the Cloudinary API.
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Message    string // Error message of the API, or the HTTP status
+}
+
+func (e *APIError) Error() string {
+	return e.Message
 }
 
 func handleHttpResponse(resp *http.Response) (map[string]interface{}, error) {
@@ -135,9 +211,33 @@ func handleHttpResponse(resp *http.Response) (map[string]interface{}, error) {
 	if resp.StatusCode != http.StatusOK {
 		// JSON error looks like {"error":{"message":"Missing required parameter - public_id"}}
 		if e, ok := m["error"]; ok {
-			return nil, errors.New(e.(map[string]interface{})["message"].(string))
+			return nil, &APIError{StatusCode: resp.StatusCode, Message: e.(map[string]interface{})["message"].(string)}
 		}
-		return nil, errors.New(resp.Status)
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: resp.Status}
 	}
 	return m, nil
 }
+
+// decodeHttpResponse decodes the JSON body of resp into result, or
+// returns the API error message if the request failed.
+func decodeHttpResponse(resp *http.Response, result interface{}) error {
+	if resp == nil {
+		return errors.New("nil http response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: resp.Status}
+		if json.NewDecoder(resp.Body).Decode(&body) == nil && body.Error.Message != "" {
+			apiErr.Message = body.Error.Message
+		}
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
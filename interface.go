@@ -1,6 +1,9 @@
 package cloudinary
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 type CloudinaryService interface {
 	// Upload a file or a set of files to the cloud. The path parameter is
@@ -26,6 +29,15 @@ type CloudinaryService interface {
 	// The function returns the public identifier of the resource.
 	Upload(path string, data io.Reader, prepend string, randomPublicID bool, rtype ResourceType) (string, error)
 
+	// UploadWithOptions is like Upload but applies opts, such as tags and
+	// context, to every uploaded file.
+	UploadWithOptions(path string, data io.Reader, prepend string, randomPublicID bool, rtype ResourceType, opts *UploadOptions) (string, error)
+
+	// UploadWithResult uploads a single file like UploadWithOptions and
+	// returns the complete upload response, such as the computed
+	// responsive breakpoints.
+	UploadWithResult(path string, data io.Reader, prepend string, randomPublicID bool, rtype ResourceType, opts *UploadOptions) (*UploadResult, error)
+
 	UploadStaticRaw(path string, data io.Reader, prepend string) (string, error)
 
 	UploadStaticImage(path string, data io.Reader, prepend string) (string, error)
@@ -38,8 +50,222 @@ type CloudinaryService interface {
 
 	UploadPdf(path string, data io.Reader, prepend string) (string, error)
 
+	// UploadVideoStreaming uploads a video and generates in the background
+	// its HLS or DASH manifest with the streaming profile.
+	UploadVideoStreaming(path string, data io.Reader, prepend, profile string, format StreamingFormat) (string, error)
+
 	// Url returns the complete access path in the cloud to the
 	// resource designed by publicId or the empty string if
 	// no match.
 	URL(publicID string, rtype ResourceType) string
+
+	// BuildURL returns the delivery URL of the resource designed by
+	// publicID with the transformations, version and format of opts.
+	BuildURL(publicID string, opts *URLOptions) string
+
+	// AuthToken sets the token options used by BuildURL to sign the URLs
+	// of authenticated resources.
+	AuthToken(opts *AuthTokenOptions) error
+
+	// HealthCheck pings the Admin API to check that the service is
+	// reachable and the credentials are valid.
+	HealthCheck() error
+
+	// Usage returns the account usage on date, or the current usage if
+	// date is zero.
+	Usage(date time.Time) (*UsageReport, error)
+
+	// RateLimit returns the rate limit status of the last Admin API
+	// response.
+	RateLimit() *RateLimit
+
+	// Sync uploads the new and changed files of localDir under prepend,
+	// and optionally deletes the remote orphans.
+	Sync(localDir, prepend string, opts *SyncOptions) (*SyncReport, error)
+
+	// UseAssetStore sets the store recording the uploaded files.
+	UseAssetStore(store AssetStore)
+
+	// Simulate enables the dry-run mode, recording the API calls changing
+	// the account in the Plan instead of sending them.
+	Simulate(v bool)
+	// Plan returns the API calls recorded in simulation mode.
+	Plan() Plan
+	// ResetPlan clears the recorded plan.
+	ResetPlan()
+	// UseLogger sets the logger of the service.
+	UseLogger(l Logger)
+	// Verbose enables the debug level tracing of the API requests and
+	// responses, logged with the Logger of the service.
+	Verbose(v bool)
+
+	// Srcset returns the srcset attribute value of an image in format,
+	// scaled to the widths of opts.
+	Srcset(publicID, format string, opts *ResponsiveOptions) string
+
+	// ImageTag returns a responsive <img> tag of an image.
+	ImageTag(publicID, alt string, opts *ResponsiveOptions) string
+
+	// PictureTag returns a <picture> tag of an image with a source set per
+	// format.
+	PictureTag(publicID, alt string, opts *ResponsiveOptions) string
+
+	// StreamingURL returns the URL of the HLS or DASH manifest of a video.
+	StreamingURL(publicID, profile string, format StreamingFormat) string
+
+	// VideoThumbnailURL returns the URL of a jpg image of the frame of a
+	// video at offset.
+	VideoThumbnailURL(publicID, offset string, transformation TransformationChain) string
+
+	// VideoPosterURL returns the URL of a jpg image of the first frame of
+	// a video.
+	VideoPosterURL(publicID string, transformation TransformationChain) string
+
+	// ListStreamingProfiles returns the adaptive streaming profiles.
+	ListStreamingProfiles() ([]*StreamingProfile, error)
+
+	// GetStreamingProfile returns a streaming profile with its
+	// representations.
+	GetStreamingProfile(name string) (*StreamingProfile, error)
+
+	// CreateStreamingProfile creates a custom streaming profile.
+	CreateStreamingProfile(profile *StreamingProfile) (*StreamingProfile, error)
+
+	// UpdateStreamingProfile updates a streaming profile.
+	UpdateStreamingProfile(name string, profile *StreamingProfile) (*StreamingProfile, error)
+
+	// DeleteStreamingProfile deletes a custom streaming profile.
+	DeleteStreamingProfile(name string) error
+
+	// Delete deletes the resource prepend+publicID of type rtype. Resources
+	// matching the KeepFiles pattern are never deleted.
+	Delete(publicID, prepend string, rtype ResourceType) error
+
+	// DeleteWithOptions is like Delete but allows to set the delivery type
+	// of the resource and to invalidate its CDN cached copies.
+	DeleteWithOptions(publicID, prepend string, rtype ResourceType, opts *DestroyOptions) error
+
+	// Rename changes the public id of the resource prepend+publicID to
+	// prepend+toPublicID.
+	Rename(publicID, toPublicID, prepend string, rtype ResourceType) error
+
+	// RenameWithOptions is like Rename but allows to change the delivery
+	// type, overwrite an existing target and invalidate cached copies.
+	RenameWithOptions(publicID, toPublicID, prepend string, rtype ResourceType, opts *RenameOptions) error
+
+	// AddTag adds tag to the resources with the given public ids and
+	// returns the public ids of the updated resources.
+	AddTag(tag string, publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// RemoveTag removes tag from the resources with the given public ids.
+	RemoveTag(tag string, publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// ReplaceTag replaces all the tags of the given resources by tag.
+	ReplaceTag(tag string, publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// RemoveAllTags removes all the tags of the given resources.
+	RemoveAllTags(publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// ListTags lists the tags used by the resources of type rtype.
+	ListTags(rtype ResourceType, opts *ListTagsOptions) (*TagList, error)
+
+	// ListResourcesByTag lists the resources of type rtype tagged with tag.
+	ListResourcesByTag(tag string, rtype ResourceType, opts *ListResourcesOptions) (*ResourceList, error)
+
+	// AddContext adds the key/value pairs of ctx to the given resources.
+	AddContext(ctx Context, publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// RemoveAllContext removes all the contextual metadata of the given
+	// resources.
+	RemoveAllContext(publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// ListMetadataFields returns the definitions of all the structured
+	// metadata fields.
+	ListMetadataFields() ([]*MetadataField, error)
+
+	// GetMetadataField returns the definition of a metadata field.
+	GetMetadataField(externalID string) (*MetadataField, error)
+
+	// CreateMetadataField creates a metadata field.
+	CreateMetadataField(field *MetadataField) (*MetadataField, error)
+
+	// UpdateMetadataField updates the definition of a metadata field.
+	UpdateMetadataField(externalID string, field *MetadataField) (*MetadataField, error)
+
+	// DeleteMetadataField deletes a metadata field.
+	DeleteMetadataField(externalID string) error
+
+	// UpdateMetadataDataSource adds or updates values of the datasource of
+	// an enum or set field.
+	UpdateMetadataDataSource(externalID string, entries []*MetadataDataSourceEntry) (*MetadataDataSource, error)
+
+	// DeleteMetadataDataSourceEntries deactivates values of the datasource
+	// of an enum or set field.
+	DeleteMetadataDataSourceEntries(externalID string, entryIDs []string) (*MetadataDataSource, error)
+
+	// RestoreMetadataDataSourceEntries reactivates deleted values of the
+	// datasource of an enum or set field.
+	RestoreMetadataDataSourceEntries(externalID string, entryIDs []string) (*MetadataDataSource, error)
+
+	// UpdateMetadata sets metadata field values on the given resources.
+	UpdateMetadata(values MetadataValues, publicIDs []string, rtype ResourceType) ([]string, error)
+
+	// NewSearch returns a Search API query matching all the resources.
+	NewSearch() *Search
+
+	// ListRootFolders lists the top level folders.
+	ListRootFolders(opts *ListFoldersOptions) (*FolderList, error)
+
+	// ListSubFolders lists the direct subfolders of path.
+	ListSubFolders(path string, opts *ListFoldersOptions) (*FolderList, error)
+
+	// CreateFolder creates the folder path, including its missing parents.
+	CreateFolder(path string) (*Folder, error)
+
+	// RenameFolder moves the folder path and its content to toPath.
+	RenameFolder(path, toPath string) error
+
+	// DeleteFolder deletes the empty folder path.
+	DeleteFolder(path string) ([]string, error)
+
+	// Explicit applies actions to an already uploaded resource, such as
+	// generating eager derived versions or updating its tags and context.
+	Explicit(publicID string, rtype ResourceType, dtype DeliveryType, opts *ExplicitOptions) (*ExplicitResult, error)
+
+	// DeleteDerivedResources deletes the derived resources with the given
+	// ids.
+	DeleteDerivedResources(ids ...string) (map[string]string, error)
+
+	// DeleteDerivedByTransformation deletes the versions of the given
+	// resources derived with any of transformations.
+	DeleteDerivedByTransformation(publicIDs []string, transformations []TransformationChain, rtype ResourceType, opts *DeleteDerivedOptions) (map[string]string, error)
+
+	// ListTransformations lists the transformations of the account.
+	ListTransformations(opts *ListTransformationsOptions) (*TransformationList, error)
+
+	// GetTransformation returns the definition and usage of a
+	// transformation.
+	GetTransformation(name string, maxDerived int) (*NamedTransformationDetails, error)
+
+	// CreateTransformation creates a named transformation.
+	CreateTransformation(name string, transformation TransformationChain) error
+
+	// UpdateTransformation sets whether a transformation is allowed when
+	// strict transformations are enabled.
+	UpdateTransformation(name string, allowedForStrict bool) error
+
+	// DeleteTransformation deletes a transformation.
+	DeleteTransformation(name string) error
+
+	// CreateArchive builds a ZIP or TGZ archive of resources and stores it
+	// in the cloud.
+	CreateArchive(rtype ResourceType, opts *ArchiveOptions) (*ArchiveResult, error)
+
+	// DownloadArchiveURL returns a signed URL generating and downloading
+	// an archive of resources on the fly.
+	DownloadArchiveURL(rtype ResourceType, opts *ArchiveOptions) string
+
+	// PrivateDownloadURL returns a signed, expiring URL downloading a
+	// private or authenticated resource.
+	PrivateDownloadURL(publicID, format string, opts *PrivateDownloadOptions) string
 }
@@ -0,0 +1,123 @@
+package cloudinary
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives the log events of the service, with structured fields
+// given as alternating keys and values, such as "public_id", "banners/1".
+// A *slog.Logger satisfies it. Debug events, tracing the API requests and
+// responses, are only emitted in verbose mode.
+type Logger interface {
+	Debug(msg string, fields ...interface{})
+	Info(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+}
+
+// UseLogger sets the logger of the service. Nothing is logged by default,
+// or if l is nil.
+func (s *cloudinaryService) UseLogger(l Logger) {
+	s.logger = l
+}
+
+// NewStdLogger returns a Logger writing one line per event to w, fields
+// formatted as key=value.
+func NewStdLogger(w io.Writer) Logger {
+	return &stdLogger{log.New(w, "", log.LstdFlags)}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (l *stdLogger) Debug(msg string, fields ...interface{}) { l.print("DEBUG", msg, fields) }
+func (l *stdLogger) Info(msg string, fields ...interface{})  { l.print("INFO", msg, fields) }
+func (l *stdLogger) Error(msg string, fields ...interface{}) { l.print("ERROR", msg, fields) }
+
+func (l *stdLogger) print(level, msg string, fields []interface{}) {
+	var b strings.Builder
+	b.WriteString(level + " " + msg)
+	for i := 0; i < len(fields); i += 2 {
+		var v interface{} = "MISSING"
+		if i+1 < len(fields) {
+			v = fields[i+1]
+		}
+		fmt.Fprintf(&b, " %v=%v", fields[i], v)
+	}
+	l.l.Print(b.String())
+}
+
+func (s *cloudinaryService) logDebug(msg string, fields ...interface{}) {
+	if s.logger != nil && s.verbose {
+		s.logger.Debug(msg, fields...)
+	}
+}
+
+func (s *cloudinaryService) logInfo(msg string, fields ...interface{}) {
+	if s.logger != nil {
+		s.logger.Info(msg, fields...)
+	}
+}
+
+func (s *cloudinaryService) logError(msg string, fields ...interface{}) {
+	if s.logger != nil {
+		s.logger.Error(msg, fields...)
+	}
+}
+
+// logOperation logs the outcome of the API operation on publicID, started
+// at start.
+func (s *cloudinaryService) logOperation(operation, publicID string, rtype ResourceType, start time.Time, status string, err error) {
+	fields := []interface{}{
+		"operation", operation,
+		"public_id", publicID,
+		"resource_type", resourceTypeName(rtype),
+		"duration", time.Since(start),
+		"status", status,
+	}
+	if err != nil {
+		s.logError(operation+" failed", append(fields, "error", err)...)
+		return
+	}
+	s.logInfo(operation, fields...)
+}
+
+// tracingTransport logs the requests sent by the service and their
+// responses at debug level.
+type tracingTransport struct {
+	base http.RoundTripper
+	s    *cloudinaryService
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.s.logger == nil || !t.s.verbose {
+		return t.base.RoundTrip(req)
+	}
+	// The query string of the Admin API requests holds no credentials,
+	// which are sent in the Authorization header
+	t.s.logDebug("request", "method", req.Method, "url", req.URL.String(), "content_length", req.ContentLength)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.s.logDebug("response", "method", req.Method, "url", req.URL.String(), "duration", time.Since(start), "error", err)
+		return nil, err
+	}
+	t.s.logDebug("response", "method", req.Method, "url", req.URL.String(), "duration", time.Since(start), "status", resp.StatusCode, "content_length", resp.ContentLength)
+	return resp, nil
+}
+
+// tracedClient returns a copy of client tracing its requests.
+func (s *cloudinaryService) tracedClient(client *http.Client) *http.Client {
+	traced := *client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	traced.Transport = &tracingTransport{base: base, s: s}
+	return &traced
+}
@@ -0,0 +1,323 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetadataFieldType is the type of the values of a structured metadata
+// field.
+type MetadataFieldType string
+
+const (
+	MetadataString  MetadataFieldType = "string"
+	MetadataInteger MetadataFieldType = "integer"
+	MetadataDate    MetadataFieldType = "date"
+	MetadataEnum    MetadataFieldType = "enum"
+	MetadataSet     MetadataFieldType = "set"
+)
+
+// metadataDateLayout is the format of date metadata values.
+const metadataDateLayout = "2006-01-02"
+
+// MetadataField is the definition of a structured metadata field.
+//
+// DefaultValue must match the field type: a string for string and enum
+// fields, an int for integer fields, a time.Time for date fields and a
+// []string of datasource external ids for set fields. Use the
+// NewStringMetadataField, NewIntegerMetadataField, NewDateMetadataField,
+// NewEnumMetadataField and NewSetMetadataField constructors to get it
+// right. Fields returned by the API are decoded the same way.
+type MetadataField struct {
+	Type         MetadataFieldType   `json:"type"`
+	ExternalId   string              `json:"external_id,omitempty"`
+	Label        string              `json:"label"`
+	Mandatory    bool                `json:"mandatory"`
+	DefaultValue interface{}         `json:"default_value,omitempty"`
+	Validation   *MetadataValidation `json:"validation,omitempty"`
+	DataSource   *MetadataDataSource `json:"datasource,omitempty"` // enum and set fields only
+}
+
+// MarshalJSON encodes date default values in the format expected by the
+// API.
+func (f MetadataField) MarshalJSON() ([]byte, error) {
+	type field MetadataField
+	if t, ok := f.DefaultValue.(time.Time); ok {
+		f.DefaultValue = t.Format(metadataDateLayout)
+	}
+	return json.Marshal(field(f))
+}
+
+// UnmarshalJSON decodes the default value to the Go type matching the
+// field type, as documented on MetadataField.
+func (f *MetadataField) UnmarshalJSON(b []byte) error {
+	type field MetadataField
+	aux := struct {
+		*field
+		DefaultValue json.RawMessage `json:"default_value,omitempty"`
+	}{field: (*field)(f)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	f.DefaultValue = nil
+	if len(aux.DefaultValue) == 0 || string(aux.DefaultValue) == "null" {
+		return nil
+	}
+	var err error
+	switch f.Type {
+	case MetadataInteger:
+		var v int
+		err = json.Unmarshal(aux.DefaultValue, &v)
+		f.DefaultValue = v
+	case MetadataDate:
+		var v string
+		if err = json.Unmarshal(aux.DefaultValue, &v); err == nil {
+			var t time.Time
+			t, err = time.Parse(metadataDateLayout, v)
+			f.DefaultValue = t
+		}
+	case MetadataSet:
+		var v []string
+		err = json.Unmarshal(aux.DefaultValue, &v)
+		f.DefaultValue = v
+	default:
+		var v string
+		err = json.Unmarshal(aux.DefaultValue, &v)
+		f.DefaultValue = v
+	}
+	if err != nil {
+		return fmt.Errorf("metadata field %s: invalid default value %s: %v", f.ExternalId, aux.DefaultValue, err)
+	}
+	return nil
+}
+
+// MetadataValidation is a validation rule of a metadata field. Type is
+// one of greater_than, less_than, strlen or and.
+type MetadataValidation struct {
+	Type   string                `json:"type"`
+	Value  interface{}           `json:"value,omitempty"`  // greater_than and less_than
+	Equals bool                  `json:"equals,omitempty"` // greater_than and less_than
+	Min    int                   `json:"min,omitempty"`    // strlen
+	Max    int                   `json:"max,omitempty"`    // strlen
+	Rules  []*MetadataValidation `json:"rules,omitempty"`  // and
+}
+
+// MetadataDataSource holds the allowed values of an enum or set field.
+type MetadataDataSource struct {
+	Values []*MetadataDataSourceEntry `json:"values"`
+}
+
+// MetadataDataSourceEntry is an allowed value of an enum or set field.
+type MetadataDataSourceEntry struct {
+	ExternalId string `json:"external_id,omitempty"`
+	Value      string `json:"value"`
+	State      string `json:"state,omitempty"` // active or inactive
+}
+
+// NewStringMetadataField returns the definition of a string field.
+func NewStringMetadataField(externalID, label string, defaultValue string) *MetadataField {
+	f := &MetadataField{Type: MetadataString, ExternalId: externalID, Label: label}
+	if defaultValue != "" {
+		f.DefaultValue = defaultValue
+	}
+	return f
+}
+
+// NewIntegerMetadataField returns the definition of an integer field.
+func NewIntegerMetadataField(externalID, label string, defaultValue *int) *MetadataField {
+	f := &MetadataField{Type: MetadataInteger, ExternalId: externalID, Label: label}
+	if defaultValue != nil {
+		f.DefaultValue = *defaultValue
+	}
+	return f
+}
+
+// NewDateMetadataField returns the definition of a date field.
+func NewDateMetadataField(externalID, label string, defaultValue time.Time) *MetadataField {
+	f := &MetadataField{Type: MetadataDate, ExternalId: externalID, Label: label}
+	if !defaultValue.IsZero() {
+		f.DefaultValue = defaultValue
+	}
+	return f
+}
+
+// NewEnumMetadataField returns the definition of an enum field accepting
+// one of values. defaultValue is the external id of a value, if any.
+func NewEnumMetadataField(externalID, label string, values []*MetadataDataSourceEntry, defaultValue string) *MetadataField {
+	f := &MetadataField{Type: MetadataEnum, ExternalId: externalID, Label: label, DataSource: &MetadataDataSource{Values: values}}
+	if defaultValue != "" {
+		f.DefaultValue = defaultValue
+	}
+	return f
+}
+
+// NewSetMetadataField returns the definition of a set field accepting
+// several of values. defaultValue holds external ids of values.
+func NewSetMetadataField(externalID, label string, values []*MetadataDataSourceEntry, defaultValue []string) *MetadataField {
+	f := &MetadataField{Type: MetadataSet, ExternalId: externalID, Label: label, DataSource: &MetadataDataSource{Values: values}}
+	if len(defaultValue) > 0 {
+		f.DefaultValue = defaultValue
+	}
+	return f
+}
+
+// MetadataValues maps metadata field external ids to their values. Values
+// are strings for string and enum fields, ints for integer fields,
+// time.Time for date fields and []string for set fields.
+type MetadataValues map[string]interface{}
+
+// encode returns the values as a key1=value1|key2=value2 string.
+func (m MetadataValues) encode() (string, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		var v string
+		switch value := m[k].(type) {
+		case string:
+			v = value
+		case int:
+			v = strconv.Itoa(value)
+		case time.Time:
+			v = value.Format(metadataDateLayout)
+		case []string:
+			b, err := json.Marshal(value)
+			if err != nil {
+				return "", err
+			}
+			v = string(b)
+		default:
+			return "", fmt.Errorf("unsupported value type %T for metadata field %s", value, k)
+		}
+		pairs[i] = contextEscaper.Replace(k) + "=" + contextEscaper.Replace(v)
+	}
+	return strings.Join(pairs, "|"), nil
+}
+
+type metadataFieldList struct {
+	MetadataFields []*MetadataField `json:"metadata_fields"`
+}
+
+// ListMetadataFields returns the definitions of all the metadata fields.
+func (s *cloudinaryService) ListMetadataFields() ([]*MetadataField, error) {
+	list := new(metadataFieldList)
+	if err := s.callAdminAPI(http.MethodGet, "metadata_fields", nil, list); err != nil {
+		return nil, err
+	}
+	return list.MetadataFields, nil
+}
+
+// GetMetadataField returns the definition of the metadata field with the
+// given external id.
+func (s *cloudinaryService) GetMetadataField(externalID string) (*MetadataField, error) {
+	field := new(MetadataField)
+	if err := s.callAdminAPI(http.MethodGet, "metadata_fields/"+url.PathEscape(externalID), nil, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// CreateMetadataField creates a metadata field and returns its definition
+// as stored by Cloudinary.
+func (s *cloudinaryService) CreateMetadataField(field *MetadataField) (*MetadataField, error) {
+	created := new(MetadataField)
+	if err := s.writeAdminAPIJSON("create_metadata_field", http.MethodPost, "metadata_fields", field, created); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return field, nil
+	}
+	return created, nil
+}
+
+// UpdateMetadataField updates the definition of the metadata field with
+// the given external id. The type of a field can't be changed.
+func (s *cloudinaryService) UpdateMetadataField(externalID string, field *MetadataField) (*MetadataField, error) {
+	updated := new(MetadataField)
+	if err := s.writeAdminAPIJSON("update_metadata_field", http.MethodPut, "metadata_fields/"+url.PathEscape(externalID), field, updated); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return field, nil
+	}
+	return updated, nil
+}
+
+// DeleteMetadataField deletes the metadata field with the given external
+// id.
+func (s *cloudinaryService) DeleteMetadataField(externalID string) error {
+	return s.writeAdminAPI("delete_metadata_field", http.MethodDelete, "metadata_fields/"+url.PathEscape(externalID), nil, nil)
+}
+
+// UpdateMetadataDataSource adds or updates entries of the datasource of
+// an enum or set field. Entries are matched by external id.
+func (s *cloudinaryService) UpdateMetadataDataSource(externalID string, entries []*MetadataDataSourceEntry) (*MetadataDataSource, error) {
+	ds := new(MetadataDataSource)
+	path := "metadata_fields/" + url.PathEscape(externalID) + "/datasource"
+	if err := s.writeAdminAPIJSON("update_metadata_datasource", http.MethodPut, path, &MetadataDataSource{Values: entries}, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// DeleteMetadataDataSourceEntries deactivates the datasource entries of
+// an enum or set field with the given external ids.
+func (s *cloudinaryService) DeleteMetadataDataSourceEntries(externalID string, entryIDs []string) (*MetadataDataSource, error) {
+	ds := new(MetadataDataSource)
+	path := "metadata_fields/" + url.PathEscape(externalID) + "/datasource"
+	body := map[string][]string{"external_ids": entryIDs}
+	if err := s.writeAdminAPIJSON("delete_metadata_datasource_entries", http.MethodDelete, path, body, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// RestoreMetadataDataSourceEntries reactivates deleted datasource entries
+// of an enum or set field.
+func (s *cloudinaryService) RestoreMetadataDataSourceEntries(externalID string, entryIDs []string) (*MetadataDataSource, error) {
+	ds := new(MetadataDataSource)
+	path := "metadata_fields/" + url.PathEscape(externalID) + "/datasource_restore"
+	body := map[string][]string{"external_ids": entryIDs}
+	if err := s.writeAdminAPIJSON("restore_metadata_datasource_entries", http.MethodPost, path, body, ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// UpdateMetadata sets the metadata field values of the resources with the
+// given public ids and returns the public ids of the updated resources.
+func (s *cloudinaryService) UpdateMetadata(values MetadataValues, publicIDs []string, rtype ResourceType) ([]string, error) {
+	metadata, err := values.encode()
+	if err != nil {
+		return nil, err
+	}
+	data := url.Values{
+		"metadata":     []string{metadata},
+		"public_ids[]": publicIDs,
+	}
+	if s.simulate {
+		s.planUploadAPI("metadata", rtype, "", 0, data)
+		return publicIDs, nil
+	}
+
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "metadata"), s.signedParameters(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	res := new(publicIdsResponse)
+	if err := decodeHttpResponse(resp, res); err != nil {
+		return nil, err
+	}
+	return res.PublicIds, nil
+}
@@ -0,0 +1,100 @@
+package cloudinary
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// NamedTransformation describes a transformation returned by the Admin
+// API. Named transformations can be referenced in delivery URLs with
+// Transformation.Named.
+type NamedTransformation struct {
+	Name             string `json:"name"`
+	AllowedForStrict bool   `json:"allowed_for_strict"`
+	Used             bool   `json:"used"`  // Whether derived resources use it
+	Named            bool   `json:"named"` // False for ad-hoc transformations
+}
+
+// NamedTransformationDetails holds the definition and usage of a
+// transformation.
+type NamedTransformationDetails struct {
+	NamedTransformation
+	Info    []map[string]interface{} `json:"info"`    // Parameters of each component of the chain
+	Derived []*Derived               `json:"derived"` // Derived resources using it
+}
+
+// TransformationList is a page of transformations returned by
+// ListTransformations.
+type TransformationList struct {
+	pagination
+	Transformations []*NamedTransformation `json:"transformations"`
+}
+
+// ListTransformationsOptions are the optional parameters of
+// ListTransformations.
+type ListTransformationsOptions struct {
+	NamedOnly  bool   // Only return named transformations
+	MaxResults int    // Maximum number of transformations to return, up to 500
+	NextCursor string // Cursor of the page to return
+}
+
+// ListTransformations lists the transformations of the account. opts may
+// be nil.
+func (s *cloudinaryService) ListTransformations(opts *ListTransformationsOptions) (*TransformationList, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.NamedOnly {
+			params.Set("named", "true")
+		}
+		if opts.MaxResults > 0 {
+			params.Set("max_results", strconv.Itoa(opts.MaxResults))
+		}
+		if opts.NextCursor != "" {
+			params.Set("next_cursor", opts.NextCursor)
+		}
+	}
+	list := new(TransformationList)
+	if err := s.callAdminAPI(http.MethodGet, "transformations", params, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetTransformation returns the definition of the transformation name and
+// up to maxDerived of the derived resources using it.
+func (s *cloudinaryService) GetTransformation(name string, maxDerived int) (*NamedTransformationDetails, error) {
+	params := url.Values{}
+	if maxDerived > 0 {
+		params.Set("max_results", strconv.Itoa(maxDerived))
+	}
+	details := new(NamedTransformationDetails)
+	if err := s.callAdminAPI(http.MethodGet, "transformations/"+url.PathEscape(name), params, details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// CreateTransformation creates the named transformation name from
+// transformation.
+func (s *cloudinaryService) CreateTransformation(name string, transformation TransformationChain) error {
+	params := url.Values{
+		"transformation": []string{transformation.String()},
+	}
+	return s.writeAdminAPI("create_transformation", http.MethodPost, "transformations/"+url.PathEscape(name), params, nil)
+}
+
+// UpdateTransformation sets whether the transformation name can be used
+// when strict transformations are enabled.
+func (s *cloudinaryService) UpdateTransformation(name string, allowedForStrict bool) error {
+	params := url.Values{
+		"allowed_for_strict": []string{strconv.FormatBool(allowedForStrict)},
+	}
+	return s.writeAdminAPI("update_transformation", http.MethodPut, "transformations/"+url.PathEscape(name), params, nil)
+}
+
+// DeleteTransformation deletes the transformation name and the derived
+// resources generated with it.
+func (s *cloudinaryService) DeleteTransformation(name string) error {
+	return s.writeAdminAPI("delete_transformation", http.MethodDelete, "transformations/"+url.PathEscape(name), nil, nil)
+}
@@ -0,0 +1,101 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/tabwriter"
+)
+
+// PlanEntry is an API call that would have been sent in simulation mode.
+type PlanEntry struct {
+	Operation    string          `json:"operation"` // Upload API action or Admin API operation
+	Method       string          `json:"method"`
+	Endpoint     string          `json:"endpoint"`
+	ResourceType string          `json:"resource_type,omitempty"`
+	PublicID     string          `json:"public_id,omitempty"`
+	Bytes        int64           `json:"bytes,omitempty"` // Size of the uploaded file
+	Params       url.Values      `json:"params,omitempty"`
+	Body         json.RawMessage `json:"body,omitempty"` // JSON request body
+}
+
+// Plan lists the API calls recorded in simulation mode, in call order.
+type Plan []*PlanEntry
+
+// WriteTable writes the plan to w as an aligned text table.
+func (p Plan) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPERATION\tMETHOD\tRESOURCE TYPE\tPUBLIC ID\tBYTES\tENDPOINT")
+	for _, e := range p {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n", e.Operation, e.Method, e.ResourceType, e.PublicID, e.Bytes, e.Endpoint)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the plan to w as an indented JSON array.
+func (p Plan) WriteJSON(w io.Writer) error {
+	if p == nil {
+		p = Plan{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// Plan returns the API calls recorded since simulation mode was enabled
+// or the plan was last reset.
+func (s *cloudinaryService) Plan() Plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(Plan(nil), s.plan...)
+}
+
+// ResetPlan clears the recorded plan.
+func (s *cloudinaryService) ResetPlan() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plan = nil
+}
+
+func (s *cloudinaryService) addPlan(e *PlanEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plan = append(s.plan, e)
+}
+
+// planUploadAPI records the call of the Upload API action with params,
+// signed as they would be sent, the signature being redacted.
+func (s *cloudinaryService) planUploadAPI(action string, rtype ResourceType, publicID string, size int64, params url.Values) {
+	signed := url.Values{}
+	for k, v := range params {
+		signed[k] = append([]string(nil), v...)
+	}
+	signed = s.signedParameters(signed)
+	signed.Set("signature", redacted)
+	s.addPlan(&PlanEntry{
+		Operation:    action,
+		Method:       http.MethodPost,
+		Endpoint:     s.uploadEndpoint(rtype, action),
+		ResourceType: resourceTypeName(rtype),
+		PublicID:     publicID,
+		Bytes:        size,
+		Params:       signed,
+	})
+}
+
+// planAdminAPI records the call of the Admin API path with params, or
+// the JSON body. The credentials are sent with basic authentication, so
+// neither holds a secret.
+func (s *cloudinaryService) planAdminAPI(operation, method, path string, params url.Values, body []byte) {
+	s.addPlan(&PlanEntry{
+		Operation: operation,
+		Method:    method,
+		Endpoint:  s.adminURL(path),
+		Params:    params,
+		Body:      body,
+	})
+}
+
+const redacted = "REDACTED"
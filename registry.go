@@ -0,0 +1,114 @@
+package cloudinary
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	goservice "github.com/baozhenglab/go-sdk/v2"
+)
+
+// Registry manages the Cloudinary accounts of several tenants. Each
+// tenant gets its own service, registered under the cloudinary-<tenant>
+// prefix:
+//
+//	registry := cloudinary.NewRegistry()
+//	service := goservice.New(
+//		goservice.WithInitRunnable(registry.Register("tenant-a")),
+//		goservice.WithInitRunnable(registry.Register("tenant-b")),
+//	)
+//	...
+//	cld, err := registry.Get("tenant-a")
+//
+// Get returns the same CloudinaryService for a tenant on every call, so
+// that its settings, such as the logger or the asset store, are kept. If
+// the service of the tenant is configured again, the next Get returns a
+// new CloudinaryService using the new configuration, and the settings of
+// the previous one must be applied again.
+type Registry struct {
+	mu       sync.RWMutex
+	services map[string]*service
+	handles  map[string]*tenantHandle // Created by the first Get
+}
+
+// tenantHandle is the CloudinaryService of a tenant, built from the
+// generation of its configuration.
+type tenantHandle struct {
+	cs         CloudinaryService
+	generation int
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{services: map[string]*service{}, handles: map[string]*tenantHandle{}}
+}
+
+// TenantPrefix returns the flags prefix of tenant.
+func TenantPrefix(tenant string) string {
+	return KeyCloudinary + "-" + tenant
+}
+
+// Register creates the service of tenant, configured by the
+// -cloudinary-<tenant>-uri flag. The returned runnable must be registered
+// in the go-sdk service. Registering a tenant twice returns the same
+// runnable.
+func (r *Registry) Register(tenant string) goservice.PrefixRunnable {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.services[tenant]; ok {
+		return s
+	}
+	s := NewCloudinaryServiceWithPrefix(TenantPrefix(tenant)).(*service)
+	r.services[tenant] = s
+	return s
+}
+
+// Get returns the CloudinaryService of tenant, created on the first call
+// after the service has been configured. It fails if the tenant is
+// unknown or its service hasn't been configured yet.
+func (r *Registry) Get(tenant string) (CloudinaryService, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.services[tenant]
+	if !ok {
+		return nil, fmt.Errorf("unknown cloudinary tenant %q", tenant)
+	}
+	c, generation := s.configGeneration()
+	if h, ok := r.handles[tenant]; ok && h.generation == generation {
+		return h.cs, nil
+	}
+	if c.cloudName == "" {
+		return nil, fmt.Errorf("cloudinary tenant %q is not configured", tenant)
+	}
+	switch v := s.Get().(type) {
+	case CloudinaryService:
+		r.handles[tenant] = &tenantHandle{cs: v, generation: generation}
+		return v, nil
+	case error:
+		return nil, v
+	default:
+		return nil, errors.New("unexpected cloudinary service type")
+	}
+}
+
+// MustGet is like Get but panics on error.
+func (r *Registry) MustGet(tenant string) CloudinaryService {
+	cs, err := r.Get(tenant)
+	if err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+// Tenants returns the registered tenants, sorted.
+func (r *Registry) Tenants() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tenants := make([]string, 0, len(r.services))
+	for t := range r.services {
+		tenants = append(tenants, t)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
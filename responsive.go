@@ -0,0 +1,257 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BreakpointSpec computes evenly spaced image widths between MinWidth and
+// MaxWidth.
+type BreakpointSpec struct {
+	MinWidth  int // 375 by default
+	MaxWidth  int // 3840 by default
+	MaxImages int // 5 by default
+}
+
+// Widths returns the widths of the spec in increasing order.
+func (b BreakpointSpec) Widths() []int {
+	min, max, n := b.MinWidth, b.MaxWidth, b.MaxImages
+	if min <= 0 {
+		min = 375
+	}
+	if max <= 0 {
+		max = 3840
+	}
+	if n <= 0 {
+		n = 5
+	}
+	if max <= min || n == 1 {
+		return []int{max}
+	}
+	widths := make([]int, 0, n)
+	step := float64(max-min) / float64(n-1)
+	for i := 0; i < n; i++ {
+		w := min + int(step*float64(i)+0.5)
+		if len(widths) == 0 || widths[len(widths)-1] != w {
+			widths = append(widths, w)
+		}
+	}
+	return widths
+}
+
+// ResponsiveOptions configure the responsive image helpers.
+type ResponsiveOptions struct {
+	// Transformation is applied before scaling the image to each width.
+	Transformation TransformationChain
+	// Widths of the images in the srcset. Breakpoints is used if empty.
+	Widths      []int
+	Breakpoints BreakpointSpec
+	// Sizes is the sizes attribute of the tags, computed from the widths
+	// if empty.
+	Sizes string
+	// Formats of the picture sources, in order of preference. The last one
+	// is used by the fallback img. Defaults to avif, webp and jpg.
+	Formats []string
+	// Attributes are added as is to the img tag, e.g. class or loading.
+	Attributes map[string]string
+}
+
+var defaultResponsiveFormats = []string{"avif", "webp", "jpg"}
+
+func (o *ResponsiveOptions) widths() []int {
+	if len(o.Widths) == 0 {
+		return o.Breakpoints.Widths()
+	}
+	widths := append([]int{}, o.Widths...)
+	sort.Ints(widths)
+	return widths
+}
+
+func (o *ResponsiveOptions) formats() []string {
+	if len(o.Formats) == 0 {
+		return defaultResponsiveFormats
+	}
+	return o.Formats
+}
+
+// scaledURL returns the URL of the image publicID scaled to width.
+func (s *cloudinaryService) scaledURL(publicID string, width int, format string, opts *ResponsiveOptions) string {
+	chain := append(TransformationChain{}, opts.Transformation...)
+	chain = append(chain, &Transformation{Width: width, Crop: "scale"})
+	return s.BuildURL(publicID, &URLOptions{Transformation: chain, Format: format})
+}
+
+// Srcset returns the srcset attribute value of the image publicID in the
+// given format, e.g. ".../c_scale,w_375/sample.jpg 375w, ...". opts may be
+// nil.
+func (s *cloudinaryService) Srcset(publicID, format string, opts *ResponsiveOptions) string {
+	if opts == nil {
+		opts = &ResponsiveOptions{}
+	}
+	widths := opts.widths()
+	candidates := make([]string, len(widths))
+	for i, w := range widths {
+		candidates[i] = s.scaledURL(publicID, w, format, opts) + " " + strconv.Itoa(w) + "w"
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// SizesAttribute returns a sizes attribute value displaying the image at
+// each width on viewports up to that width, and full width on wider ones,
+// e.g. "(max-width: 375px) 375px, (max-width: 750px) 750px, 100vw".
+func SizesAttribute(widths []int) string {
+	widths = append([]int{}, widths...)
+	sort.Ints(widths)
+	sizes := make([]string, 0, len(widths)+1)
+	for _, w := range widths {
+		px := strconv.Itoa(w) + "px"
+		sizes = append(sizes, "(max-width: "+px+") "+px)
+	}
+	return strings.Join(append(sizes, "100vw"), ", ")
+}
+
+func (o *ResponsiveOptions) sizes() string {
+	if o.Sizes != "" {
+		return o.Sizes
+	}
+	return SizesAttribute(o.widths())
+}
+
+// ImageTag returns an <img> tag of the image publicID with a srcset in the
+// fallback format of opts. opts may be nil.
+func (s *cloudinaryService) ImageTag(publicID, alt string, opts *ResponsiveOptions) string {
+	if opts == nil {
+		opts = &ResponsiveOptions{}
+	}
+	formats := opts.formats()
+	format := formats[len(formats)-1]
+	widths := opts.widths()
+
+	attrs := map[string]string{
+		"src":    s.scaledURL(publicID, widths[len(widths)-1], format, opts),
+		"srcset": s.Srcset(publicID, format, opts),
+		"sizes":  opts.sizes(),
+		"alt":    alt,
+	}
+	for k, v := range opts.Attributes {
+		attrs[k] = v
+	}
+	return "<img" + htmlAttributes(attrs) + ">"
+}
+
+// PictureTag returns a <picture> tag of the image publicID with a <source>
+// per format of opts and an <img> fallback. opts may be nil.
+func (s *cloudinaryService) PictureTag(publicID, alt string, opts *ResponsiveOptions) string {
+	if opts == nil {
+		opts = &ResponsiveOptions{}
+	}
+	formats := opts.formats()
+	var b strings.Builder
+	b.WriteString("<picture>")
+	for _, format := range formats[:len(formats)-1] {
+		attrs := map[string]string{
+			"type":   imageMIMEType(format),
+			"srcset": s.Srcset(publicID, format, opts),
+			"sizes":  opts.sizes(),
+		}
+		b.WriteString("<source" + htmlAttributes(attrs) + ">")
+	}
+	b.WriteString(s.ImageTag(publicID, alt, opts))
+	b.WriteString("</picture>")
+	return b.String()
+}
+
+// imageMIMETypes maps the image formats whose MIME type isn't
+// image/<format>.
+var imageMIMETypes = map[string]string{
+	"jpg":  "image/jpeg",
+	"jpe":  "image/jpeg",
+	"jpeg": "image/jpeg",
+	"svg":  "image/svg+xml",
+	"tif":  "image/tiff",
+	"ico":  "image/x-icon",
+	"jxr":  "image/vnd.ms-photo",
+	"wdp":  "image/vnd.ms-photo",
+	"hdp":  "image/vnd.ms-photo",
+}
+
+// imageMIMEType returns the MIME type of the image format, such as
+// image/jpeg for jpg.
+func imageMIMEType(format string) string {
+	format = strings.ToLower(format)
+	if t, ok := imageMIMETypes[format]; ok {
+		return t
+	}
+	return "image/" + format
+}
+
+// htmlAttributes returns attrs as escaped HTML attributes sorted by name,
+// each one preceded by a space.
+func htmlAttributes(attrs map[string]string) string {
+	names := make([]string, 0, len(attrs))
+	for k := range attrs {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(" " + html.EscapeString(k) + `="` + html.EscapeString(attrs[k]) + `"`)
+	}
+	return b.String()
+}
+
+// ResponsiveBreakpointsOptions request Cloudinary to compute, on upload,
+// the image widths which differ by about BytesStep bytes.
+type ResponsiveBreakpointsOptions struct {
+	CreateDerived  bool                // Generate the derived images of the breakpoints
+	BytesStep      int                 // Minimum file size difference between breakpoints, 20000 by default
+	MinWidth       int                 // 50 by default
+	MaxWidth       int                 // 1000 by default
+	MaxImages      int                 // 20 by default
+	Transformation TransformationChain // Applied before computing the breakpoints
+	Format         string              // Format of the derived images
+}
+
+// MarshalJSON encodes o as an entry of the responsive_breakpoints upload
+// parameter.
+func (o ResponsiveBreakpointsOptions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		CreateDerived  bool   `json:"create_derived"`
+		BytesStep      int    `json:"bytes_step,omitempty"`
+		MinWidth       int    `json:"min_width,omitempty"`
+		MaxWidth       int    `json:"max_width,omitempty"`
+		MaxImages      int    `json:"max_images,omitempty"`
+		Transformation string `json:"transformation,omitempty"`
+		Format         string `json:"format,omitempty"`
+	}{o.CreateDerived, o.BytesStep, o.MinWidth, o.MaxWidth, o.MaxImages, o.Transformation.String(), o.Format})
+}
+
+// ResponsiveBreakpoints holds the breakpoints computed for one of the
+// requested ResponsiveBreakpointsOptions.
+type ResponsiveBreakpoints struct {
+	Transformation string        `json:"transformation"`
+	Breakpoints    []*Breakpoint `json:"breakpoints"` // By decreasing width
+}
+
+// Breakpoint is an optimal width of an image.
+type Breakpoint struct {
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Size      int    `json:"bytes"`      // In bytes
+	Url       string `json:"url"`        // Remote url
+	SecureUrl string `json:"secure_url"` // Over https
+}
+
+// Widths returns the widths of the breakpoints in increasing order, as
+// expected by ResponsiveOptions.Widths.
+func (r *ResponsiveBreakpoints) Widths() []int {
+	widths := make([]int, len(r.Breakpoints))
+	for i, b := range r.Breakpoints {
+		widths[i] = b.Width
+	}
+	sort.Ints(widths)
+	return widths
+}
@@ -0,0 +1,241 @@
+package cloudinary
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SearchExpr is a Search API expression, such as
+// resource_type:image AND tags=kitten. Build them with SearchField and
+// combine them with And, Or and Not.
+type SearchExpr string
+
+// SearchField is a field of the Search API expressions, such as tags,
+// format, bytes, created_at or metadata.<external_id>.
+type SearchField string
+
+// searchValue quotes value when it holds characters that have a meaning
+// in expressions.
+func searchValue(value string) string {
+	if strings.ContainsAny(value, " \t:=<>()[]!\"") {
+		return `"` + strings.Replace(value, `"`, `\"`, -1) + `"`
+	}
+	return value
+}
+
+// Is matches resources whose field contains value, e.g. tags:kitten.
+func (f SearchField) Is(value string) SearchExpr {
+	return SearchExpr(string(f) + ":" + searchValue(value))
+}
+
+// Equals matches resources whose field is exactly value, e.g.
+// format=png.
+func (f SearchField) Equals(value string) SearchExpr {
+	return SearchExpr(string(f) + "=" + searchValue(value))
+}
+
+// GreaterThan matches resources whose field is greater than value, e.g.
+// bytes>1mb or created_at>1w.
+func (f SearchField) GreaterThan(value string) SearchExpr {
+	return SearchExpr(string(f) + ">" + searchValue(value))
+}
+
+// LessThan matches resources whose field is less than value.
+func (f SearchField) LessThan(value string) SearchExpr {
+	return SearchExpr(string(f) + "<" + searchValue(value))
+}
+
+// Between matches resources whose field is in the inclusive range
+// [from, to], e.g. created_at:[2020-01-01 TO 2020-02-01].
+func (f SearchField) Between(from, to string) SearchExpr {
+	return SearchExpr(string(f) + ":[" + searchValue(from) + " TO " + searchValue(to) + "]")
+}
+
+// Since matches resources whose date field is after t, such as
+// created_at or uploaded_at.
+func (f SearchField) Since(t time.Time) SearchExpr {
+	return SearchExpr(string(f) + ">" + searchValue(t.UTC().Format(time.RFC3339)))
+}
+
+// And matches resources matching e and all the others expressions.
+func (e SearchExpr) And(others ...SearchExpr) SearchExpr {
+	return e.join("AND", others)
+}
+
+// Or matches resources matching e or any of the others expressions.
+func (e SearchExpr) Or(others ...SearchExpr) SearchExpr {
+	return e.join("OR", others)
+}
+
+// Not matches resources not matching e.
+func (e SearchExpr) Not() SearchExpr {
+	return SearchExpr("NOT (" + string(e) + ")")
+}
+
+func (e SearchExpr) join(op string, others []SearchExpr) SearchExpr {
+	if len(others) == 0 {
+		return e
+	}
+	parts := make([]string, 0, len(others)+1)
+	for _, o := range append([]SearchExpr{e}, others...) {
+		parts = append(parts, "("+string(o)+")")
+	}
+	return SearchExpr(strings.Join(parts, " "+op+" "))
+}
+
+// SortDirection is the sort order of a search.
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SearchResource is a resource returned by the Search API.
+type SearchResource struct {
+	Resource
+	Folder    string                 `json:"folder"`
+	Filename  string                 `json:"filename"`
+	CreatedAt time.Time              `json:"created_at"`
+	Width     int                    `json:"width"`
+	Height    int                    `json:"height"`
+	Metadata  map[string]interface{} `json:"metadata"` // Requires WithField("metadata")
+}
+
+// SearchResult is a page of search results.
+type SearchResult struct {
+	pagination
+	TotalCount int               `json:"total_count"`
+	Time       int               `json:"time"` // Query time in milliseconds
+	Resources  []*SearchResource `json:"resources"`
+	// Aggregations maps each aggregated field to the count of resources
+	// per value, e.g. {"format": {"png": 12, "jpg": 3}}.
+	Aggregations map[string]map[string]int `json:"aggregations"`
+}
+
+type searchQuery struct {
+	Expression SearchExpr                 `json:"expression,omitempty"`
+	SortBy     []map[string]SortDirection `json:"sort_by,omitempty"`
+	Aggregate  []string                   `json:"aggregate,omitempty"`
+	WithField  []string                   `json:"with_field,omitempty"`
+	MaxResults int                        `json:"max_results,omitempty"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+}
+
+// Search is a query of the Search API. Create one with NewSearch, set its
+// parameters with the chainable methods and run it with Execute or
+// Iterator.
+type Search struct {
+	service *cloudinaryService
+	query   searchQuery
+}
+
+// NewSearch returns an empty search query, matching all the resources.
+func (s *cloudinaryService) NewSearch() *Search {
+	return &Search{service: s}
+}
+
+// Expression sets the expression resources must match.
+func (q *Search) Expression(expr SearchExpr) *Search {
+	q.query.Expression = expr
+	return q
+}
+
+// SortBy adds a sort criteria, e.g. SortBy("created_at", SortDesc).
+func (q *Search) SortBy(field string, dir SortDirection) *Search {
+	q.query.SortBy = append(q.query.SortBy, map[string]SortDirection{field: dir})
+	return q
+}
+
+// Aggregate requests the count of resources per value of field, one of
+// resource_type, type, pixels, duration, format or bytes.
+func (q *Search) Aggregate(field string) *Search {
+	q.query.Aggregate = append(q.query.Aggregate, field)
+	return q
+}
+
+// WithField includes an additional field in the results, one of
+// context, tags, image_metadata or metadata.
+func (q *Search) WithField(field string) *Search {
+	q.query.WithField = append(q.query.WithField, field)
+	return q
+}
+
+// MaxResults sets the number of resources per page, up to 500.
+func (q *Search) MaxResults(n int) *Search {
+	q.query.MaxResults = n
+	return q
+}
+
+// NextCursor sets the cursor of the page to return.
+func (q *Search) NextCursor(cursor string) *Search {
+	q.query.NextCursor = cursor
+	return q
+}
+
+// Execute runs the query and returns a page of results.
+func (q *Search) Execute() (*SearchResult, error) {
+	res := new(SearchResult)
+	if err := q.service.callAdminAPIJSON(http.MethodPost, "resources/search", q.query, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Iterator returns an iterator over the resources of all the pages of
+// results, starting at the page set by NextCursor.
+func (q *Search) Iterator() *SearchIterator {
+	return &SearchIterator{search: &Search{service: q.service, query: q.query}}
+}
+
+// SearchIterator iterates over the resources matching a search, fetching
+// the pages as needed:
+//
+//	it := s.NewSearch().Expression("tags=kitten").Iterator()
+//	for it.Next() {
+//		r := it.Resource()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type SearchIterator struct {
+	search *Search
+	page   *SearchResult
+	index  int
+	err    error
+}
+
+// Next advances to the next resource and reports whether there is one.
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.page != nil && it.index+1 < len(it.page.Resources) {
+		it.index++
+		return true
+	}
+	for it.page == nil || it.page.NextCursor != "" {
+		if it.page != nil {
+			it.search.NextCursor(it.page.NextCursor)
+		}
+		it.page, it.err = it.search.Execute()
+		if it.err != nil {
+			return false
+		}
+		if len(it.page.Resources) > 0 {
+			it.index = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Resource returns the current resource.
+func (it *SearchIterator) Resource() *SearchResource {
+	return it.page.Resources[it.index]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,212 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AssetRecord holds the information stored about an uploaded file.
+type AssetRecord struct {
+	PublicID     string    `json:"public_id"`
+	Path         string    `json:"path"` // Local file the resource was uploaded from
+	ResourceType string    `json:"resource_type"`
+	Version      uint      `json:"version"`
+	Format       string    `json:"format"`
+	Size         int       `json:"bytes"`    // In bytes
+	Checksum     string    `json:"checksum"` // MD5 checksum, the etag of the resource
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// AssetStore stores the information about the uploaded files. When set
+// with UseAssetStore, the service records every successful upload and
+// removes or updates the records on Delete and Rename.
+type AssetStore interface {
+	// Record adds or replaces the record of rec.PublicID.
+	Record(rec *AssetRecord) error
+	// ByPath returns the last record of the file path, or nil if none.
+	ByPath(path string) (*AssetRecord, error)
+	// ByPublicID returns the record of publicID, or nil if none.
+	ByPublicID(publicID string) (*AssetRecord, error)
+	// Delete removes the record of publicID, if any.
+	Delete(publicID string) error
+	// List returns all the records sorted by public id.
+	List() ([]*AssetRecord, error)
+}
+
+// UseAssetStore sets the store recording the uploaded files. A nil store
+// disables recording.
+func (s *cloudinaryService) UseAssetStore(store AssetStore) {
+	s.store = store
+}
+
+// recordUpload stores the result of the upload of the file path.
+func (s *cloudinaryService) recordUpload(path string, upInfo *UploadResult) error {
+	if s.store == nil {
+		return nil
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return s.store.Record(&AssetRecord{
+		PublicID:     upInfo.PublicId,
+		Path:         path,
+		ResourceType: upInfo.ResourceType,
+		Version:      upInfo.Version,
+		Format:       upInfo.Format,
+		Size:         upInfo.Size,
+		Checksum:     upInfo.Etag,
+		UpdatedAt:    time.Now(),
+	})
+}
+
+// recordRename moves the record of publicID to toPublicID.
+func (s *cloudinaryService) recordRename(publicID, toPublicID string) error {
+	if s.store == nil {
+		return nil
+	}
+	rec, err := s.store.ByPublicID(publicID)
+	if err != nil || rec == nil {
+		return err
+	}
+	if err := s.store.Delete(publicID); err != nil {
+		return err
+	}
+	renamed := *rec
+	renamed.PublicID = toPublicID
+	renamed.UpdatedAt = time.Now()
+	return s.store.Record(&renamed)
+}
+
+// MemoryAssetStore is an AssetStore keeping the records in memory.
+type MemoryAssetStore struct {
+	mu      sync.RWMutex
+	records map[string]*AssetRecord
+}
+
+// NewMemoryAssetStore returns an empty in-memory store.
+func NewMemoryAssetStore() *MemoryAssetStore {
+	return &MemoryAssetStore{records: map[string]*AssetRecord{}}
+}
+
+// Record adds or replaces the record of rec.PublicID.
+func (m *MemoryAssetStore) Record(rec *AssetRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *rec
+	m.records[rec.PublicID] = &cp
+	return nil
+}
+
+// ByPath returns the most recent record of the file path, or nil if none.
+func (m *MemoryAssetStore) ByPath(path string) (*AssetRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found *AssetRecord
+	for _, rec := range m.records {
+		if rec.Path == path && (found == nil || rec.UpdatedAt.After(found.UpdatedAt)) {
+			found = rec
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	cp := *found
+	return &cp, nil
+}
+
+// ByPublicID returns the record of publicID, or nil if none.
+func (m *MemoryAssetStore) ByPublicID(publicID string) (*AssetRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	rec, ok := m.records[publicID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *rec
+	return &cp, nil
+}
+
+// Delete removes the record of publicID, if any.
+func (m *MemoryAssetStore) Delete(publicID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, publicID)
+	return nil
+}
+
+// List returns all the records sorted by public id.
+func (m *MemoryAssetStore) List() ([]*AssetRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	list := make([]*AssetRecord, 0, len(m.records))
+	for _, rec := range m.records {
+		cp := *rec
+		list = append(list, &cp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].PublicID < list[j].PublicID })
+	return list, nil
+}
+
+// FileAssetStore is an AssetStore persisting the records in a JSON file,
+// rewritten after every change.
+type FileAssetStore struct {
+	MemoryAssetStore
+	path string
+	wmu  sync.Mutex // Serializes the writes of the file
+}
+
+// NewFileAssetStore returns a store persisted in the file path, loading
+// its records if the file exists.
+func NewFileAssetStore(path string) (*FileAssetStore, error) {
+	f := &FileAssetStore{path: path}
+	f.records = map[string]*AssetRecord{}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []*AssetRecord
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		f.records[rec.PublicID] = rec
+	}
+	return f, nil
+}
+
+// Record adds or replaces the record of rec.PublicID.
+func (f *FileAssetStore) Record(rec *AssetRecord) error {
+	f.MemoryAssetStore.Record(rec)
+	return f.save()
+}
+
+// Delete removes the record of publicID, if any.
+func (f *FileAssetStore) Delete(publicID string) error {
+	f.MemoryAssetStore.Delete(publicID)
+	return f.save()
+}
+
+// save writes the records to a temporary file renamed over the store
+// file, so that it's never left half written.
+func (f *FileAssetStore) save() error {
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	records, _ := f.List()
+	b, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
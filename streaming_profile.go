@@ -0,0 +1,222 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StreamingProfile is an adaptive streaming profile: the set of video
+// representations, one per bitrate, of HLS and DASH manifests.
+type StreamingProfile struct {
+	Name            string                     `json:"name"`
+	DisplayName     string                     `json:"display_name"`
+	Predefined      bool                       `json:"predefined"` // Built-in profiles can't be deleted
+	Representations []*StreamingRepresentation `json:"representations"`
+}
+
+// StreamingRepresentation is a video representation of a streaming
+// profile.
+type StreamingRepresentation struct {
+	// Transformation of the representation, e.g. w_1280,h_720,c_limit,
+	// vc_h264:main:3.1,br_3.5m.
+	Transformation TransformationChain
+	// Info holds the parameters of each component of the transformation,
+	// as returned by the Admin API. Transformation is rebuilt from it when
+	// decoding, so that fetched profiles can be updated as is; it's left
+	// nil if Info holds parameters unknown to this package.
+	Info []map[string]interface{}
+}
+
+// MarshalJSON encodes r as expected when creating or updating a profile.
+func (r StreamingRepresentation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"transformation": r.Transformation.String()})
+}
+
+// UnmarshalJSON decodes the representation returned by the Admin API.
+func (r *StreamingRepresentation) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Transformation []map[string]interface{} `json:"transformation"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	r.Info = v.Transformation
+	r.Transformation = transformationFromInfo(v.Transformation)
+	return nil
+}
+
+// transformationCodes maps the transformation parameter names used by the
+// Admin API to their URL codes.
+var transformationCodes = map[string]string{
+	"width":             "w",
+	"height":            "h",
+	"crop":              "c",
+	"gravity":           "g",
+	"aspect_ratio":      "ar",
+	"quality":           "q",
+	"fetch_format":      "f",
+	"effect":            "e",
+	"radius":            "r",
+	"angle":             "a",
+	"background":        "b",
+	"dpr":               "dpr",
+	"flags":             "fl",
+	"start_offset":      "so",
+	"end_offset":        "eo",
+	"duration":          "du",
+	"bit_rate":          "br",
+	"video_codec":       "vc",
+	"audio_codec":       "ac",
+	"fps":               "fps",
+	"keyframe_interval": "ki",
+	"streaming_profile": "sp",
+}
+
+// transformationFromInfo rebuilds the transformation described by the
+// parameters of each component, or returns nil if a parameter is unknown
+// or isn't a string or a number.
+func transformationFromInfo(info []map[string]interface{}) TransformationChain {
+	if len(info) == 0 {
+		return nil
+	}
+	chain := make(TransformationChain, 0, len(info))
+	for _, component := range info {
+		keys := make([]string, 0, len(component))
+		for k := range component {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			code, ok := transformationCodes[k]
+			if !ok {
+				return nil
+			}
+			var v string
+			switch value := component[k].(type) {
+			case string:
+				v = value
+			case float64:
+				v = strconv.FormatFloat(value, 'f', -1, 64)
+			default:
+				return nil
+			}
+			parts = append(parts, code+"_"+v)
+		}
+		chain = append(chain, &Transformation{Raw: strings.Join(parts, ",")})
+	}
+	return chain
+}
+
+type streamingProfileList struct {
+	Data []*StreamingProfile `json:"data"`
+}
+
+type streamingProfileResponse struct {
+	Data *StreamingProfile `json:"data"`
+}
+
+// ListStreamingProfiles returns the predefined and custom streaming
+// profiles, without their representations.
+func (s *cloudinaryService) ListStreamingProfiles() ([]*StreamingProfile, error) {
+	list := new(streamingProfileList)
+	if err := s.callAdminAPI(http.MethodGet, "streaming_profiles", nil, list); err != nil {
+		return nil, err
+	}
+	return list.Data, nil
+}
+
+// GetStreamingProfile returns the streaming profile name.
+func (s *cloudinaryService) GetStreamingProfile(name string) (*StreamingProfile, error) {
+	res := new(streamingProfileResponse)
+	if err := s.callAdminAPI(http.MethodGet, "streaming_profiles/"+url.PathEscape(name), nil, res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// CreateStreamingProfile creates a custom streaming profile.
+func (s *cloudinaryService) CreateStreamingProfile(profile *StreamingProfile) (*StreamingProfile, error) {
+	params, err := profile.values()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("name", profile.Name)
+	res := new(streamingProfileResponse)
+	if err := s.writeAdminAPI("create_streaming_profile", http.MethodPost, "streaming_profiles", params, res); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return profile, nil
+	}
+	return res.Data, nil
+}
+
+// UpdateStreamingProfile replaces the display name and representations of
+// the streaming profile name.
+func (s *cloudinaryService) UpdateStreamingProfile(name string, profile *StreamingProfile) (*StreamingProfile, error) {
+	params, err := profile.values()
+	if err != nil {
+		return nil, err
+	}
+	res := new(streamingProfileResponse)
+	if err := s.writeAdminAPI("update_streaming_profile", http.MethodPut, "streaming_profiles/"+url.PathEscape(name), params, res); err != nil {
+		return nil, err
+	}
+	if s.simulate {
+		return profile, nil
+	}
+	return res.Data, nil
+}
+
+// DeleteStreamingProfile deletes the custom streaming profile name, or
+// reverts a predefined one to its default settings.
+func (s *cloudinaryService) DeleteStreamingProfile(name string) error {
+	return s.writeAdminAPI("delete_streaming_profile", http.MethodDelete, "streaming_profiles/"+url.PathEscape(name), nil, nil)
+}
+
+func (p *StreamingProfile) values() (url.Values, error) {
+	params := url.Values{}
+	if p.DisplayName != "" {
+		params.Set("display_name", p.DisplayName)
+	}
+	if len(p.Representations) > 0 {
+		for i, r := range p.Representations {
+			if r.Transformation.String() == "" {
+				return nil, fmt.Errorf("streaming profile %s: representation %d has no transformation", p.Name, i)
+			}
+		}
+		b, err := json.Marshal(p.Representations)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("representations", string(b))
+	}
+	return params, nil
+}
+
+// StreamingEager returns the eager transformation generating the HLS or
+// DASH manifest of a video with profile.
+func StreamingEager(profile string, format StreamingFormat) Eager {
+	return Eager{
+		Transformation: TransformationChain{{StreamingProfile: profile}},
+		Format:         string(format),
+	}
+}
+
+// UploadVideoStreaming uploads a video like UploadVideo and generates in
+// the background its HLS or DASH manifest with profile, to be delivered
+// with StreamingURL.
+func (s *cloudinaryService) UploadVideoStreaming(path string, data io.Reader, prepend, profile string, format StreamingFormat) (string, error) {
+	opts := &UploadOptions{
+		Eager:      []Eager{StreamingEager(profile, format)},
+		EagerAsync: true,
+	}
+	return s.UploadWithOptions(path, data, prepend, false, VideoType, opts)
+}
@@ -0,0 +1,225 @@
+package cloudinary
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SyncAction is the action taken by Sync on a file or remote resource.
+type SyncAction string
+
+const (
+	SyncCreate    SyncAction = "create"    // New local file uploaded
+	SyncUpdate    SyncAction = "update"    // Changed local file uploaded again
+	SyncUnchanged SyncAction = "unchanged" // Same checksum, nothing done
+	SyncDelete    SyncAction = "delete"    // Remote orphan deleted
+	SyncKeep      SyncAction = "keep"      // Remote orphan matching KeepFiles
+)
+
+// SyncOptions are the optional parameters of Sync.
+type SyncOptions struct {
+	ResourceType  ResourceType   // Image by default
+	DeleteOrphans bool           // Delete the remote resources without local file
+	UploadOptions *UploadOptions // Applied to the uploaded files
+	UseStore      bool           // Compare with the asset store instead of listing the cloud
+}
+
+// SyncItem is the action taken by Sync on a file or remote resource.
+type SyncItem struct {
+	Action   SyncAction
+	Path     string // Local file, empty for remote orphans
+	PublicID string
+	Checksum string // MD5 checksum of the local file
+}
+
+// SyncReport lists the actions taken by Sync, or planned in simulation
+// mode, sorted by public id.
+type SyncReport struct {
+	Items []*SyncItem
+}
+
+// Count returns the number of items with action.
+func (r *SyncReport) Count(action SyncAction) int {
+	n := 0
+	for _, it := range r.Items {
+		if it.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Sync makes the resources under prepend mirror the files of localDir:
+// new files and files whose checksum differs from the remote etag are
+// uploaded, and, if opts.DeleteOrphans is set, remote resources without
+// local file are deleted, except the ones matching the KeepFiles pattern.
+// With opts.UseStore the remote checksums are read from the asset store
+// instead of listing the cloud.
+// Public ids are computed as by Upload: unless opts.UploadOptions sets
+// CreateFolders, the tree is flattened and file names must be unique
+// across it. In simulation mode nothing is changed, the report holds
+// the planned actions and the uploads and deletions are recorded in the
+// Plan. opts may be nil.
+func (s *cloudinaryService) Sync(localDir, prepend string, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	job := &uploadJob{rtype: opts.ResourceType, opts: opts.UploadOptions, prepend: prepend}
+	if info, err := os.Stat(localDir); err == nil && info.IsDir() {
+		job.baseDir = localDir
+	}
+	// The files of subdirectories are uploaded in subfolders
+	nested := job.opts != nil && job.opts.CreateFolders
+	local, err := s.localChecksums(localDir, job)
+	if err != nil {
+		return nil, err
+	}
+	var remote map[string]string
+	if opts.UseStore && s.store != nil {
+		remote, err = s.storedEtags(prepend, opts.ResourceType, nested)
+	} else {
+		remote, err = s.remoteEtags(prepend, opts.ResourceType, nested)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(SyncReport)
+	for publicID, item := range local {
+		etag, exists := remote[publicID]
+		switch {
+		case !exists:
+			item.Action = SyncCreate
+		case etag != item.Checksum:
+			item.Action = SyncUpdate
+		default:
+			item.Action = SyncUnchanged
+		}
+		report.Items = append(report.Items, item)
+	}
+	if opts.DeleteOrphans {
+		for publicID := range remote {
+			if _, exists := local[publicID]; exists {
+				continue
+			}
+			item := &SyncItem{Action: SyncDelete, PublicID: publicID}
+			if s.keepFilesPattern != nil && s.keepFilesPattern.MatchString(publicID) {
+				item.Action = SyncKeep
+			}
+			report.Items = append(report.Items, item)
+		}
+	}
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].PublicID < report.Items[j].PublicID
+	})
+	for _, item := range report.Items {
+		switch item.Action {
+		case SyncCreate, SyncUpdate:
+			if _, err := s.uploadFile(job, item.Path, nil, false); err != nil {
+				return report, err
+			}
+		case SyncDelete:
+			if err := s.Delete(item.PublicID, "", opts.ResourceType); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// localChecksums returns the files of dir keyed by the public id job
+// gives them, with their MD5 checksum.
+func (s *cloudinaryService) localChecksums(dir string, job *uploadJob) (map[string]*SyncItem, error) {
+	items := map[string]*SyncItem{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		publicID := job.publicID(path)
+		if other, ok := items[publicID]; ok {
+			return fmt.Errorf("sync: %s and %s both map to public id %s", other.Path, path, publicID)
+		}
+		sum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		items[publicID] = &SyncItem{Path: path, PublicID: publicID, Checksum: sum}
+		return nil
+	})
+	return items, err
+}
+
+// remoteEtags returns the etags of the resources of type rtype directly
+// under prepend, or in any of its subfolders if nested is set, keyed by
+// public id.
+func (s *cloudinaryService) remoteEtags(prepend string, rtype ResourceType, nested bool) (map[string]string, error) {
+	// Same prefix as the public ids computed by CleanExtensionNameWithPrepend
+	prefix := strings.TrimPrefix(strings.TrimSpace(prepend), "/")
+	if prefix != "" {
+		prefix = EnsureTrailingSlash(prefix)
+	}
+	expr := SearchField("resource_type").Equals(resourceTypeName(rtype)).And(SearchField("type").Equals(string(DeliveryUpload)))
+	switch {
+	case prefix != "" && nested:
+		expr = expr.And(SearchField("folder").Is(prefix + "*"))
+	case prefix != "":
+		expr = expr.And(SearchField("folder").Equals(strings.TrimSuffix(prefix, "/")))
+	}
+
+	etags := map[string]string{}
+	it := s.NewSearch().Expression(expr).MaxResults(500).Iterator()
+	for it.Next() {
+		r := it.Resource()
+		name := strings.TrimPrefix(r.PublicId, prefix)
+		if !strings.HasPrefix(r.PublicId, prefix) || (!nested && strings.Contains(name, "/")) {
+			continue
+		}
+		etags[r.PublicId] = r.Etag
+	}
+	return etags, it.Err()
+}
+
+// storedEtags is like remoteEtags but reads the checksums recorded in the
+// asset store.
+func (s *cloudinaryService) storedEtags(prepend string, rtype ResourceType, nested bool) (map[string]string, error) {
+	prefix := strings.TrimPrefix(strings.TrimSpace(prepend), "/")
+	if prefix != "" {
+		prefix = EnsureTrailingSlash(prefix)
+	}
+	records, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+	etags := map[string]string{}
+	for _, rec := range records {
+		name := strings.TrimPrefix(rec.PublicID, prefix)
+		if !strings.HasPrefix(rec.PublicID, prefix) || (!nested && strings.Contains(name, "/")) {
+			continue
+		}
+		if rec.ResourceType != "" && rec.ResourceType != resourceTypeName(rtype) {
+			continue
+		}
+		etags[rec.PublicID] = rec.Checksum
+	}
+	return etags, nil
+}
+
+// fileChecksum returns the MD5 checksum of the file path, as the etag of
+// the Cloudinary resources.
+func fileChecksum(path string) (string, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, fd); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,135 @@
+package cloudinary
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TagList is a page of tags returned by ListTags.
+type TagList struct {
+	pagination
+	Tags []string `json:"tags"`
+}
+
+// ListTagsOptions are the optional parameters of ListTags.
+type ListTagsOptions struct {
+	Prefix     string // Only return tags starting with Prefix
+	MaxResults int    // Maximum number of tags to return, up to 500
+	NextCursor string // Cursor of the page to return
+}
+
+// ListResourcesOptions are the optional parameters of the Admin API calls
+// listing resources.
+type ListResourcesOptions struct {
+	MaxResults int    // Maximum number of resources to return, up to 500
+	NextCursor string // Cursor of the page to return
+	Tags       bool   // Include the tags of each resource
+	Context    bool   // Include the contextual metadata of each resource
+}
+
+func (o *ListResourcesOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if o.MaxResults > 0 {
+		params.Set("max_results", strconv.Itoa(o.MaxResults))
+	}
+	if o.NextCursor != "" {
+		params.Set("next_cursor", o.NextCursor)
+	}
+	if o.Tags {
+		params.Set("tags", "true")
+	}
+	if o.Context {
+		params.Set("context", "true")
+	}
+	return params
+}
+
+type publicIdsResponse struct {
+	PublicIds []string `json:"public_ids"`
+}
+
+// AddTag adds tag to the resources with the given public ids and returns
+// the public ids of the updated resources.
+func (s *cloudinaryService) AddTag(tag string, publicIDs []string, rtype ResourceType) ([]string, error) {
+	return s.tags("add", tag, publicIDs, rtype)
+}
+
+// RemoveTag removes tag from the resources with the given public ids.
+func (s *cloudinaryService) RemoveTag(tag string, publicIDs []string, rtype ResourceType) ([]string, error) {
+	return s.tags("remove", tag, publicIDs, rtype)
+}
+
+// ReplaceTag replaces all the tags of the resources with the given public
+// ids by tag.
+func (s *cloudinaryService) ReplaceTag(tag string, publicIDs []string, rtype ResourceType) ([]string, error) {
+	return s.tags("replace", tag, publicIDs, rtype)
+}
+
+// RemoveAllTags removes all the tags of the resources with the given
+// public ids.
+func (s *cloudinaryService) RemoveAllTags(publicIDs []string, rtype ResourceType) ([]string, error) {
+	return s.tags("remove_all", "", publicIDs, rtype)
+}
+
+func (s *cloudinaryService) tags(command, tag string, publicIDs []string, rtype ResourceType) ([]string, error) {
+	data := url.Values{
+		"command":      []string{command},
+		"public_ids[]": publicIDs,
+	}
+	if tag != "" {
+		data.Set("tag", tag)
+	}
+	if s.simulate {
+		s.planUploadAPI("tags", rtype, "", 0, data)
+		return publicIDs, nil
+	}
+
+	resp, err := s.client.PostForm(s.uploadEndpoint(rtype, "tags"), s.signedParameters(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	res := new(publicIdsResponse)
+	if err := decodeHttpResponse(resp, res); err != nil {
+		return nil, err
+	}
+	return res.PublicIds, nil
+}
+
+// ListTags lists the tags used by the resources of type rtype. opts may
+// be nil.
+func (s *cloudinaryService) ListTags(rtype ResourceType, opts *ListTagsOptions) (*TagList, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Prefix != "" {
+			params.Set("prefix", opts.Prefix)
+		}
+		if opts.MaxResults > 0 {
+			params.Set("max_results", strconv.Itoa(opts.MaxResults))
+		}
+		if opts.NextCursor != "" {
+			params.Set("next_cursor", opts.NextCursor)
+		}
+	}
+	list := new(TagList)
+	if err := s.callAdminAPI(http.MethodGet, "tags/"+resourceTypeName(rtype), params, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ListResourcesByTag lists the resources of type rtype tagged with tag.
+// opts may be nil.
+func (s *cloudinaryService) ListResourcesByTag(tag string, rtype ResourceType, opts *ListResourcesOptions) (*ResourceList, error) {
+	list := new(ResourceList)
+	path := "resources/" + resourceTypeName(rtype) + "/tags/" + url.PathEscape(tag)
+	if err := s.callAdminAPI(http.MethodGet, path, opts.values(), list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
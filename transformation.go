@@ -0,0 +1,133 @@
+package cloudinary
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Transformation is one component of a transformation chain, such as
+// c_fill,g_face,h_200,w_300. Zero fields are left out.
+type Transformation struct {
+	Width       int      // w_
+	Height      int      // h_
+	Crop        string   // c_, e.g. fill, fit, scale, thumb
+	Gravity     string   // g_, e.g. face, auto, north
+	AspectRatio string   // ar_, e.g. 16:9
+	Quality     string   // q_, e.g. auto, 80
+	FetchFormat string   // f_, e.g. auto, webp
+	Effect      string   // e_, e.g. grayscale, blur:300
+	Radius      string   // r_, e.g. 20, max
+	Angle       int      // a_
+	Background  string   // b_, e.g. white, rgb:ff0000
+	DPR         string   // dpr_, e.g. 2.0, auto
+	Flags       []string // fl_, e.g. progressive
+	Named       string   // t_, name of a named transformation
+
+	// Video parameters
+	StartOffset      string // so_, seconds or percents, e.g. 2.5, 10p
+	EndOffset        string // eo_, seconds or percents
+	Duration         string // du_, seconds or percents
+	BitRate          string // br_, e.g. 500k, 2m
+	VideoCodec       string // vc_, e.g. h264, h265:main, auto
+	AudioCodec       string // ac_, e.g. aac, none
+	FPS              string // fps_, e.g. 25, 15-30
+	KeyframeInterval string // ki_, seconds
+	StreamingProfile string // sp_, e.g. hd, full_hd, or a custom profile name
+
+	// Raw holds additional parameters appended as is, e.g. "l_logo,o_50".
+	Raw string
+}
+
+// params returns the URL parameters of t keyed by their prefix.
+func (t *Transformation) params() map[string]string {
+	p := map[string]string{}
+	set := func(key, value string) {
+		if value != "" {
+			p[key] = value
+		}
+	}
+	if t.Width > 0 {
+		set("w", strconv.Itoa(t.Width))
+	}
+	if t.Height > 0 {
+		set("h", strconv.Itoa(t.Height))
+	}
+	if t.Angle != 0 {
+		set("a", strconv.Itoa(t.Angle))
+	}
+	set("c", t.Crop)
+	set("g", t.Gravity)
+	set("ar", t.AspectRatio)
+	set("q", t.Quality)
+	set("f", t.FetchFormat)
+	set("e", t.Effect)
+	set("r", t.Radius)
+	set("b", t.Background)
+	set("dpr", t.DPR)
+	set("fl", strings.Join(t.Flags, "."))
+	set("t", t.Named)
+	set("so", t.StartOffset)
+	set("eo", t.EndOffset)
+	set("du", t.Duration)
+	set("br", t.BitRate)
+	set("vc", t.VideoCodec)
+	set("ac", t.AudioCodec)
+	set("fps", t.FPS)
+	set("ki", t.KeyframeInterval)
+	set("sp", t.StreamingProfile)
+	return p
+}
+
+// String returns the URL representation of t, with the parameters sorted
+// by name.
+func (t *Transformation) String() string {
+	p := t.params()
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		parts = append(parts, k+"_"+p[k])
+	}
+	if t.Raw != "" {
+		parts = append(parts, t.Raw)
+	}
+	return strings.Join(parts, ",")
+}
+
+// TransformationChain is a list of transformations applied in order.
+type TransformationChain []*Transformation
+
+// String returns the URL representation of the chain, the components
+// being separated by slashes.
+func (c TransformationChain) String() string {
+	parts := make([]string, 0, len(c))
+	for _, t := range c {
+		if s := t.String(); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// Eager is a derived version of a resource generated on upload or by
+// Explicit.
+type Eager struct {
+	Transformation TransformationChain
+	Format         string // Target format of the derived version, e.g. jpg
+}
+
+// encodeEager returns the value of the eager parameter of the upload API.
+func encodeEager(eager []Eager) string {
+	parts := make([]string, len(eager))
+	for i, e := range eager {
+		parts[i] = e.Transformation.String()
+		if e.Format != "" {
+			parts[i] += "/" + e.Format
+		}
+	}
+	return strings.Join(parts, "|")
+}
@@ -2,121 +2,174 @@ package cloudinary
 
 import (
 	"bytes"
-	"crypto/sha1"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 )
 
-// Upload file to the service. When using a mongoDB database for storing
-// file information (such as checksums), the database is updated after
-// any successful upload.
-func (s *cloudinaryService) uploadFile(fullPath string, data io.Reader, randomPublicId bool) (string, error) {
-
-	buf := new(bytes.Buffer)
-	w := multipart.NewWriter(buf)
+// UploadOptions are the optional parameters of UploadWithOptions.
+type UploadOptions struct {
+	Tags    []string // Tags assigned to the uploaded resources
+	Context Context  // Key/value contextual metadata of the uploaded resources
+	// CreateFolders pre-creates, under the prepend path, the folder tree
+	// mirroring the uploaded directory, and uploads its files in these
+	// folders: dir/sub/a.png gets the public id <prepend>/sub/a instead of
+	// <prepend>/a.
+	CreateFolders bool
+	// ResponsiveBreakpoints requests the computation of the optimal widths
+	// of the uploaded images, returned in UploadResult.
+	ResponsiveBreakpoints []*ResponsiveBreakpointsOptions
+	// Eager derived versions generated on upload, such as streaming
+	// manifests of videos, see StreamingEager.
+	Eager                []Eager
+	EagerAsync           bool   // Generate the eager versions in the background
+	EagerNotificationURL string // Called when asynchronous eager generation completes
+}
 
-	// Write public ID
-	var publicId string
-	if !randomPublicId {
-		// publicId = cleanAssetName(fullPath, s.basePathDir, s.prependPath)
-		// make the  publictId looks like a regular file path, such as /banners/1.jpg but actually
-		// the publicId is banners/1.jpg
-		publicId = CleanExtensionNameWithPrepend(fullPath, s.prependPath)
-		pi, err := w.CreateFormField("public_id")
-		if err != nil {
-			return fullPath, err
+// values returns the upload API parameters of o. o may be nil.
+func (o *UploadOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if len(o.Tags) > 0 {
+		params.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if len(o.Context) > 0 {
+		params.Set("context", o.Context.encode())
+	}
+	if len(o.Eager) > 0 {
+		params.Set("eager", encodeEager(o.Eager))
+	}
+	if o.EagerAsync {
+		params.Set("eager_async", "true")
+	}
+	if o.EagerNotificationURL != "" {
+		params.Set("eager_notification_url", o.EagerNotificationURL)
+	}
+	if len(o.ResponsiveBreakpoints) > 0 {
+		b, err := json.Marshal(o.ResponsiveBreakpoints)
+		if err == nil {
+			params.Set("responsive_breakpoints", string(b))
 		}
-		pi.Write([]byte(publicId))
 	}
-	// Write API key
-	ak, err := w.CreateFormField("api_key")
-	if err != nil {
-		return fullPath, err
+	return params
+}
+
+// uploadJob holds the parameters of an Upload call, shared by the files
+// of an uploaded directory. It's kept out of the service so that
+// concurrent uploads don't mix their parameters.
+type uploadJob struct {
+	rtype   ResourceType
+	opts    *UploadOptions
+	baseDir string // Uploaded directory, empty for a single file
+	prepend string // Remote prepend path
+}
+
+// publicID returns the public id of the uploaded file fullPath.
+func (j *uploadJob) publicID(fullPath string) string {
+	if j.baseDir != "" && j.opts != nil && j.opts.CreateFolders {
+		// Keep the directory tree, mirrored by the folders created in walkIt
+		return cleanAssetName(fullPath, j.baseDir, j.prepend)
 	}
-	ak.Write([]byte(s.apiKey))
+	// make the  publictId looks like a regular file path, such as /banners/1.jpg but actually
+	// the publicId is banners/1.jpg
+	return CleanExtensionNameWithPrepend(fullPath, j.prepend)
+}
 
-	// Write timestamp
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
-	ts, err := w.CreateFormField("timestamp")
-	if err != nil {
+// Upload file to the service. When using an AssetStore for storing file
+// information (such as checksums), the store is updated after any
+// successful upload.
+func (s *cloudinaryService) uploadFile(job *uploadJob, fullPath string, data io.Reader, randomPublicId bool) (string, error) {
+	upInfo, err := s.uploadFileResult(job, fullPath, data, randomPublicId)
+	if err != nil || upInfo == nil {
 		return fullPath, err
 	}
-	ts.Write([]byte(timestamp))
+	return upInfo.PublicId, nil
+}
 
-	// Write signature
-	hash := sha1.New()
-	part := fmt.Sprintf("timestamp=%s%s", timestamp, s.apiSecret)
+// uploadFileResult uploads a file and returns the upload response, or nil
+// in simulation mode.
+func (s *cloudinaryService) uploadFileResult(job *uploadJob, fullPath string, data io.Reader, randomPublicId bool) (*UploadResult, error) {
+	params := job.opts.values()
 	if !randomPublicId {
-		part = fmt.Sprintf("public_id=%s&%s", publicId, part)
+		params.Set("public_id", job.publicID(fullPath))
+	}
+	if s.simulate {
+		size, err := uploadSize(fullPath, data)
+		if err != nil {
+			return nil, err
+		}
+		s.planUploadAPI("upload", job.rtype, params.Get("public_id"), size, params)
+		return nil, nil
 	}
-	io.WriteString(hash, part)
-	signature := fmt.Sprintf("%x", hash.Sum(nil))
 
-	si, err := w.CreateFormField("signature")
-	if err != nil {
-		return fullPath, err
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+
+	// Write the signed parameters, api key and timestamp included
+	params = s.signedParameters(params)
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range params[k] {
+			if err := w.WriteField(k, v); err != nil {
+				return nil, err
+			}
+		}
 	}
-	si.Write([]byte(signature))
 
 	// Write file field
 	fw, err := w.CreateFormFile("file", fullPath)
 	if err != nil {
-		return fullPath, err
+		return nil, err
 	}
 	if data != nil { // file descriptor given
 		tmp, err := ioutil.ReadAll(data)
 		if err != nil {
-			return fullPath, err
+			return nil, err
 		}
 		fw.Write(tmp)
 	} else { // no file descriptor, try opening the file
 		fd, err := os.Open(fullPath)
 		if err != nil {
-			return fullPath, err
+			return nil, err
 		}
 		defer fd.Close()
 
 		_, err = io.Copy(fw, fd)
 		if err != nil {
-			return fullPath, err
+			return nil, err
 		}
-		log.Printf("Uploading: %s\n", fullPath)
 	}
 	// Don't forget to close the multipart writer to get a terminating boundary
 	w.Close()
-	if s.simulate {
-		return fullPath, nil
-	}
-
-	upURI := s.uploadURI.String()
 
-	if s.uploadResType == PdfType {
-		upURI = strings.Replace(upURI, imageType, pdfType, 1)
-	} else if s.uploadResType == VideoType {
-		upURI = strings.Replace(upURI, imageType, videoType, 1)
-	} else if s.uploadResType == RawType {
-		upURI = strings.Replace(upURI, imageType, rawType, 1)
-	}
-	req, err := http.NewRequest("POST", upURI, buf)
+	req, err := http.NewRequest("POST", s.uploadEndpoint(job.rtype, "upload"), buf)
 	if err != nil {
-		return fullPath, err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", w.FormDataContentType())
-	resp, err := http.DefaultClient.Do(req)
+	publicID := params.Get("public_id")
+	s.logDebug("uploading", "operation", "upload", "path", fullPath, "public_id", publicID, "resource_type", resourceTypeName(job.rtype))
+	start := time.Now()
+	resp, err := s.client.Do(req)
 
 	if err != nil {
-		return fullPath, err
+		s.logOperation("upload", publicID, job.rtype, start, "", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -124,16 +177,35 @@ func (s *cloudinaryService) uploadFile(fullPath string, data io.Reader, randomPu
 		// Body is JSON data and looks like:
 		// {"public_id":"Downloads/file","version":1369431906,"format":"png","resource_type":"image"}
 		dec := json.NewDecoder(resp.Body)
-		upInfo := new(uploadResponse)
+		upInfo := new(UploadResult)
 		if err := dec.Decode(upInfo); err != nil {
-			return fullPath, err
+			s.logOperation("upload", publicID, job.rtype, start, resp.Status, err)
+			return nil, err
 		}
-		accessURL := getAccessURL(s.uploadResType, s.cloudName, upInfo.PublicId, upInfo.Format)
-		log.Printf("URL: %s\n", accessURL)
-		return upInfo.PublicId, nil
+		s.logOperation("upload", upInfo.PublicId, job.rtype, start, resp.Status, nil)
+		s.logDebug("uploaded", "operation", "upload", "public_id", upInfo.PublicId, "url", s.getAccessURL(job.rtype, upInfo.PublicId, upInfo.Format))
+		if err := s.recordUpload(fullPath, upInfo); err != nil {
+			return upInfo, err
+		}
+		return upInfo, nil
 	} else {
-		return fullPath, errors.New("Request error: " + resp.Status)
+		err := errors.New("Request error: " + resp.Status)
+		s.logOperation("upload", publicID, job.rtype, start, resp.Status, err)
+		return nil, err
+	}
+}
+
+// uploadSize returns the size of the file uploaded from data, or from the
+// file fullPath if data is nil.
+func uploadSize(fullPath string, data io.Reader) (int64, error) {
+	if data != nil {
+		return io.Copy(ioutil.Discard, data)
 	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
 }
 
 // helpers
@@ -183,9 +255,13 @@ func (s *cloudinaryService) UploadPdf(path string, data io.Reader, prepend strin
 //
 // The function returns the public identifier of the resource.
 func (s *cloudinaryService) Upload(path string, data io.Reader, prepend string, randomPublicId bool, rtype ResourceType) (string, error) {
-	s.uploadResType = rtype
-	s.basePathDir = ""
-	s.prependPath = prepend
+	return s.UploadWithOptions(path, data, prepend, randomPublicId, rtype, nil)
+}
+
+// UploadWithOptions is like Upload but applies opts, which may be nil, to
+// every uploaded file.
+func (s *cloudinaryService) UploadWithOptions(path string, data io.Reader, prepend string, randomPublicId bool, rtype ResourceType, opts *UploadOptions) (string, error) {
+	job := &uploadJob{rtype: rtype, opts: opts, prepend: prepend}
 	if data == nil {
 		info, err := os.Stat(path)
 		if err != nil {
@@ -193,25 +269,69 @@ func (s *cloudinaryService) Upload(path string, data io.Reader, prepend string,
 		}
 
 		if info.IsDir() {
-			s.basePathDir = path
-			if err := filepath.Walk(path, s.walkIt); err != nil {
+			job.baseDir = path
+			walk := func(p string, info os.FileInfo, err error) error {
+				return s.walkIt(job, p, info, err)
+			}
+			if err := filepath.Walk(path, walk); err != nil {
 				return path, err
 			}
 		} else {
-			return s.uploadFile(path, nil, randomPublicId)
+			return s.uploadFile(job, path, nil, randomPublicId)
 		}
 	} else {
-		return s.uploadFile(path, data, randomPublicId)
+		return s.uploadFile(job, path, data, randomPublicId)
 	}
 	return path, nil
 }
 
-func (s *cloudinaryService) walkIt(path string, info os.FileInfo, err error) error {
+// UploadWithResult uploads the single file path like UploadWithOptions
+// and returns the complete upload response, including the computed
+// responsive breakpoints. It returns a nil result in simulation mode.
+func (s *cloudinaryService) UploadWithResult(path string, data io.Reader, prepend string, randomPublicId bool, rtype ResourceType, opts *UploadOptions) (*UploadResult, error) {
+	job := &uploadJob{rtype: rtype, opts: opts, prepend: prepend}
+	if data == nil {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			return nil, errors.New("UploadWithResult requires a file, got directory " + path)
+		}
+	}
+	return s.uploadFileResult(job, path, data, randomPublicId)
+}
+
+func (s *cloudinaryService) walkIt(job *uploadJob, path string, info os.FileInfo, err error) error {
+	if err != nil {
+		return err
+	}
 	if info.IsDir() {
+		if job.opts != nil && job.opts.CreateFolders {
+			return s.createMirrorFolder(job, path)
+		}
 		return nil
 	}
-	if _, err := s.uploadFile(path, nil, false); err != nil {
+	if _, err := s.uploadFile(job, path, nil, false); err != nil {
 		return err
 	}
 	return nil
 }
+
+// createMirrorFolder creates the remote folder matching the local
+// directory dir of the uploaded tree.
+func (s *cloudinaryService) createMirrorFolder(job *uploadJob, dir string) error {
+	rel, err := filepath.Rel(job.baseDir, dir)
+	if err != nil {
+		return err
+	}
+	folder := strings.Trim(job.prepend, "/")
+	if rel != "." {
+		folder = strings.Trim(folder+"/"+filepath.ToSlash(rel), "/")
+	}
+	if folder == "" {
+		return nil
+	}
+	_, err = s.CreateFolder(folder)
+	return err
+}
@@ -0,0 +1,158 @@
+package cloudinary
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+const (
+	sharedCDN    = "res.cloudinary.com"
+	oldAkamaiCDN = "cloudinary-a.akamaihd.net"
+)
+
+// URLOptions are the optional parameters of BuildURL.
+type URLOptions struct {
+	ResourceType   ResourceType        // Image by default
+	Type           DeliveryType        // Delivery type, upload by default
+	Transformation TransformationChain // Transformations applied on delivery
+	Version        int                 // Version of the resource, v<version> path segment
+	Format         string              // Extension appended to the public id, e.g. jpg
+	// AuthToken overrides the token options set with AuthToken to sign the
+	// URL of an authenticated resource.
+	AuthToken *AuthTokenOptions
+	// URLSuffix is appended to the public id in SEO friendly URLs, such as
+	// .../images/<public_id>/<suffix>.jpg. It requires a private CDN or a
+	// secure distribution, and only applies to the upload, private and
+	// authenticated types. It's ignored, as Cloudinary would answer 404,
+	// without them or if it contains a dot or a slash.
+	URLSuffix string
+	// UseRootPath removes the resource and delivery types from the URL of
+	// uploaded images. It requires a private CDN or a custom domain.
+	UseRootPath bool
+	// Shorten replaces the image/upload path of uploaded images by iu.
+	Shorten bool
+}
+
+// BuildURL returns the delivery URL of the resource designed by publicID,
+// applying opts which may be nil. The URL honours the secure, cname,
+// private_cdn, secure_distribution and cdn_subdomain settings.
+func (s *cloudinaryService) BuildURL(publicID string, opts *URLOptions) string {
+	if opts == nil {
+		opts = &URLOptions{}
+	}
+	if opts.URLSuffix != "" && !s.validURLSuffix(opts.URLSuffix) {
+		o := *opts
+		o.URLSuffix = ""
+		opts = &o
+	}
+	dtype := opts.Type
+	if dtype == "" {
+		dtype = DeliveryUpload
+	}
+	publicID = strings.TrimPrefix(publicID, "/")
+	source := publicID
+	if opts.URLSuffix != "" {
+		source += "/" + opts.URLSuffix
+	}
+	if opts.Format != "" {
+		source += "." + opts.Format
+	}
+
+	parts := []string{s.urlPrefix(publicID)}
+	if typePath := resourceTypePath(resourceTypeName(opts.ResourceType), dtype, opts); typePath != "" {
+		parts = append(parts, typePath)
+	}
+	if t := opts.Transformation.String(); t != "" {
+		parts = append(parts, t)
+	}
+	if opts.Version > 0 {
+		parts = append(parts, "v"+strconv.Itoa(opts.Version))
+	}
+	uri := strings.Join(append(parts, source), "/")
+	if dtype == DeliveryAuthenticated {
+		uri = s.signedURL(uri, opts.AuthToken)
+	}
+	return uri
+}
+
+// validURLSuffix tells whether the URL suffix can be used with the
+// configuration of the account.
+func (s *cloudinaryService) validURLSuffix(suffix string) bool {
+	if strings.ContainsAny(suffix, "./") {
+		return false
+	}
+	return s.privateCDN || s.secureDistribution != ""
+}
+
+// resourceTypePath returns the resource and delivery types path of a
+// delivery URL, such as image/upload, iu or images.
+func resourceTypePath(rtype string, dtype DeliveryType, opts *URLOptions) string {
+	if opts.URLSuffix != "" {
+		switch {
+		case rtype == imageType && dtype == DeliveryUpload:
+			return "images"
+		case rtype == imageType && dtype == DeliveryPrivate:
+			return "private_images"
+		case rtype == imageType && dtype == DeliveryAuthenticated:
+			return "authenticated_images"
+		case rtype == rawType && dtype == DeliveryUpload:
+			return "files"
+		case rtype == videoType && dtype == DeliveryUpload:
+			return "videos"
+		}
+	}
+	if rtype == imageType && dtype == DeliveryUpload {
+		if opts.UseRootPath {
+			return ""
+		}
+		if opts.Shorten {
+			return "iu"
+		}
+	}
+	return rtype + "/" + string(dtype)
+}
+
+// urlPrefix returns the scheme, host and, on the shared CDN, cloud name of
+// the delivery URLs. The CDN subdomain is picked from publicID so that a
+// resource is always delivered from the same one.
+func (s *cloudinaryService) urlPrefix(publicID string) string {
+	shard := crc32.ChecksumIEEE([]byte(publicID))%5 + 1
+	sharedDomain := !s.privateCDN
+	var prefix string
+	if s.secure {
+		dist := s.secureDistribution
+		if dist == "" || dist == oldAkamaiCDN {
+			if s.privateCDN {
+				dist = s.cloudName + "-res.cloudinary.com"
+			} else {
+				dist = sharedCDN
+			}
+		}
+		sharedDomain = sharedDomain || dist == sharedCDN
+		if sharedDomain && s.cdnSubdomain {
+			dist = strings.Replace(dist, "res.cloudinary.com", fmt.Sprintf("res-%d.cloudinary.com", shard), 1)
+		}
+		prefix = "https://" + dist
+	} else if s.cname != "" {
+		subdomain := ""
+		if s.cdnSubdomain {
+			subdomain = fmt.Sprintf("a%d.", shard)
+		}
+		prefix = "http://" + subdomain + s.cname
+	} else {
+		subdomain := "res"
+		if s.privateCDN {
+			subdomain = s.cloudName + "-res"
+		}
+		if s.cdnSubdomain {
+			subdomain += fmt.Sprintf("-%d", shard)
+		}
+		prefix = "http://" + subdomain + ".cloudinary.com"
+	}
+	if sharedDomain {
+		prefix += "/" + s.cloudName
+	}
+	return prefix
+}
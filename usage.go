@@ -0,0 +1,91 @@
+package cloudinary
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// UsageMetric is the usage of a quota of the plan.
+type UsageMetric struct {
+	Usage        float64 `json:"usage"`         // In bytes for storage and bandwidth
+	CreditsUsage float64 `json:"credits_usage"` // Credits consumed by this usage
+	Limit        float64 `json:"limit"`         // Zero if the metric has no limit of its own
+	UsedPercent  float64 `json:"used_percent"`
+}
+
+// UsageReport is the account usage returned by Usage.
+type UsageReport struct {
+	Plan             string      `json:"plan"`
+	LastUpdated      string      `json:"last_updated"` // YYYY-MM-DD
+	Storage          UsageMetric `json:"storage"`
+	Bandwidth        UsageMetric `json:"bandwidth"`
+	Transformations  UsageMetric `json:"transformations"`
+	Objects          UsageMetric `json:"objects"`
+	Credits          UsageMetric `json:"credits"`
+	Requests         int64       `json:"requests"`
+	Resources        int64       `json:"resources"`
+	DerivedResources int64       `json:"derived_resources"`
+}
+
+// Usage returns the account usage on date, or the current usage if date
+// is zero.
+func (s *cloudinaryService) Usage(date time.Time) (*UsageReport, error) {
+	path := "usage"
+	if !date.IsZero() {
+		path += "/" + date.Format("02-01-2006")
+	}
+	report := new(UsageReport)
+	if err := s.callAdminAPI(http.MethodGet, path, nil, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// RateLimit is the Admin API rate limit status, as reported by the
+// X-FeatureRateLimit-* headers of the responses.
+type RateLimit struct {
+	Limit     int       // Requests allowed per hour
+	Remaining int       // Requests left before Reset
+	Reset     time.Time // Time the limit is reset
+}
+
+// parseRateLimit returns the rate limit status of resp, or nil if the
+// response has none.
+func parseRateLimit(resp *http.Response) *RateLimit {
+	limit, err := strconv.Atoi(resp.Header.Get("X-FeatureRateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
+	rl := &RateLimit{Limit: limit}
+	rl.Remaining, _ = strconv.Atoi(resp.Header.Get("X-FeatureRateLimit-Remaining"))
+	rl.Reset, _ = http.ParseTime(resp.Header.Get("X-FeatureRateLimit-Reset"))
+	return rl
+}
+
+// rateLimitTracker holds the rate limit status of the last Admin API
+// response of an account, shared by the CloudinaryService instances
+// returned by Get.
+type rateLimitTracker struct {
+	mu   sync.Mutex
+	last *RateLimit
+}
+
+func (t *rateLimitTracker) set(rl *RateLimit) {
+	t.mu.Lock()
+	t.last = rl
+	t.mu.Unlock()
+}
+
+func (t *rateLimitTracker) get() *RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+// RateLimit returns the rate limit status of the last Admin API response
+// of the account, or nil if no request has been sent yet.
+func (s *cloudinaryService) RateLimit() *RateLimit {
+	return s.rateLimits.get()
+}
@@ -0,0 +1,44 @@
+package cloudinary
+
+// StreamingFormat is the manifest format of adaptive bitrate streaming.
+type StreamingFormat string
+
+const (
+	StreamingHLS  StreamingFormat = "m3u8"
+	StreamingDASH StreamingFormat = "mpd"
+)
+
+// StreamingURL returns the URL of the HLS or DASH manifest of the video
+// publicID, streamed with profile such as hd or full_hd. The manifest must
+// have been generated eagerly with the same profile, see
+// UploadVideoStreaming and StreamingEager.
+func (s *cloudinaryService) StreamingURL(publicID, profile string, format StreamingFormat) string {
+	return s.BuildURL(publicID, &URLOptions{
+		ResourceType:   VideoType,
+		Transformation: TransformationChain{{StreamingProfile: profile}},
+		Format:         string(format),
+	})
+}
+
+// VideoThumbnailURL returns the URL of a jpg image of the frame of the
+// video publicID at offset, in seconds (e.g. 2.5) or percents (e.g. 10p),
+// with transformation applied.
+func (s *cloudinaryService) VideoThumbnailURL(publicID, offset string, transformation TransformationChain) string {
+	chain := append(TransformationChain{{StartOffset: offset}}, transformation...)
+	return s.BuildURL(publicID, &URLOptions{
+		ResourceType:   VideoType,
+		Transformation: chain,
+		Format:         "jpg",
+	})
+}
+
+// VideoPosterURL returns the URL of a jpg image of the first frame of the
+// video publicID, suitable for the poster attribute of a <video> tag, with
+// transformation applied.
+func (s *cloudinaryService) VideoPosterURL(publicID string, transformation TransformationChain) string {
+	return s.BuildURL(publicID, &URLOptions{
+		ResourceType:   VideoType,
+		Transformation: transformation,
+		Format:         "jpg",
+	})
+}